@@ -0,0 +1,104 @@
+package esi
+
+import "context"
+
+// AlliancesEndpoint handles communication with the alliances related methods
+// of the ESI API.
+type AlliancesEndpoint endpoint
+
+// List returns the IDs of all active player alliances.
+func (e *AlliancesEndpoint) List(ctx context.Context) ([]int, *Response, error) {
+	u := e.api.route("get_alliances")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// AlliancePublicInfo holds public information about an alliance.
+type AlliancePublicInfo struct {
+	CreatorCorporationID  *int       `json:"creator_corporation_id,omitempty"`
+	CreatorID             *int       `json:"creator_id,omitempty"`
+	DateFounded           *Timestamp `json:"date_founded,omitempty"`
+	ExecutorCorporationID *int       `json:"executor_corporation_id,omitempty"`
+	FactionID             *int       `json:"faction_id,omitempty"`
+	Name                  *string    `json:"name,omitempty"`
+	Ticker                *string    `json:"ticker,omitempty"`
+}
+
+func (s AlliancePublicInfo) String() string {
+	return Stringify(s)
+}
+
+// Get returns public information about an alliance.
+func (e *AlliancesEndpoint) Get(ctx context.Context, aid int) (*AlliancePublicInfo, *Response, error) {
+	u := e.api.route("get_alliances_alliance_id", aid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	alliancePublicInfo := new(AlliancePublicInfo)
+	resp, err := e.api.Do(ctx, req, alliancePublicInfo)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return alliancePublicInfo, resp, nil
+}
+
+// GetCorporations returns the IDs of the member corporations of an alliance.
+func (e *AlliancesEndpoint) GetCorporations(ctx context.Context, aid int) ([]int, *Response, error) {
+	u := e.api.route("get_alliances_alliance_id_corporations", aid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// AllianceIcons holds the URLs of an alliance's icon in various sizes.
+type AllianceIcons struct {
+	Px64x64   *string `json:"px64x64,omitempty"`
+	Px128x128 *string `json:"px128x128,omitempty"`
+}
+
+func (s AllianceIcons) String() string {
+	return Stringify(s)
+}
+
+// GetIcons returns the icon URLs of an alliance.
+func (e *AlliancesEndpoint) GetIcons(ctx context.Context, aid int) (*AllianceIcons, *Response, error) {
+	u := e.api.route("get_alliances_alliance_id_icons", aid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allianceIcons := new(AllianceIcons)
+	resp, err := e.api.Do(ctx, req, allianceIcons)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return allianceIcons, resp, nil
+}
@@ -0,0 +1,112 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAlliancesEndpoint_List(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/alliances/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[99000001, 99000002]`)
+	})
+
+	ids, _, err := client.Alliances.List(context.Background())
+	if err != nil {
+		t.Errorf("Alliances.List returned error: %v", err)
+	}
+
+	if want := []int{99000001, 99000002}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Alliances.List returned %+v, want %+v", ids, want)
+	}
+}
+
+func TestAlliancesEndpoint_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/alliances/42/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			{
+				"creator_corporation_id": 45678,
+				"creator_id": 12345,
+				"date_founded": "2016-06-26T21:00:00Z",
+				"executor_corporation_id": 98356193,
+				"name": "C C P Alliance",
+				"ticker": "<C C P>"
+			}
+		`)
+	})
+
+	info, _, err := client.Alliances.Get(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Alliances.Get returned error: %v", err)
+	}
+
+	want := &AlliancePublicInfo{
+		CreatorCorporationID:  Int(45678),
+		CreatorID:             Int(12345),
+		DateFounded:           &Timestamp{time.Date(2016, 6, 26, 21, 0, 0, 0, time.UTC)},
+		ExecutorCorporationID: Int(98356193),
+		Name:                  String("C C P Alliance"),
+		Ticker:                String("<C C P>"),
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("Alliances.Get returned %+v, want %+v", info, want)
+	}
+}
+
+func TestAlliancesEndpoint_GetCorporations(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/alliances/42/corporations/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[98000001]`)
+	})
+
+	ids, _, err := client.Alliances.GetCorporations(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Alliances.GetCorporations returned error: %v", err)
+	}
+
+	if want := []int{98000001}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Alliances.GetCorporations returned %+v, want %+v", ids, want)
+	}
+}
+
+func TestAlliancesEndpoint_GetIcons(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/alliances/42/icons/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			{
+				"px128x128": "https://imageserver.eveonline.com/Alliance/503818424_128.png",
+				"px64x64": "https://imageserver.eveonline.com/Alliance/503818424_64.png"
+			}
+		`)
+	})
+
+	icons, _, err := client.Alliances.GetIcons(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Alliances.GetIcons returned error: %v", err)
+	}
+
+	want := &AllianceIcons{
+		Px64x64:   String("https://imageserver.eveonline.com/Alliance/503818424_64.png"),
+		Px128x128: String("https://imageserver.eveonline.com/Alliance/503818424_128.png"),
+	}
+	if !reflect.DeepEqual(icons, want) {
+		t.Errorf("Alliances.GetIcons returned %+v, want %+v", icons, want)
+	}
+}
@@ -0,0 +1,227 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+)
+
+// MaxAssetIDs is the maximum number of item IDs accepted by a single asset
+// locations or names request. Larger sets are split into several requests.
+const MaxAssetIDs = 1000
+
+// ErrNoAssetIDs is returned without making a request when asset locations or
+// names are requested for no items. It matches ErrBadRequest.
+var ErrNoAssetIDs = fmt.Errorf("%w: no item IDs", ErrBadRequest)
+
+// AssetsEndpoint handles communication with the assets related methods of the
+// ESI API.
+type AssetsEndpoint endpoint
+
+// Asset holds a single item owned by a character or corporation.
+type Asset struct {
+	IsBlueprintCopy *bool   `json:"is_blueprint_copy,omitempty"`
+	IsSingleton     *bool   `json:"is_singleton,omitempty"`
+	ItemID          *int64  `json:"item_id,omitempty"`
+	LocationFlag    *string `json:"location_flag,omitempty"`
+	LocationID      *int64  `json:"location_id,omitempty"`
+	LocationType    *string `json:"location_type,omitempty"`
+	Quantity        *int    `json:"quantity,omitempty"`
+	TypeID          *int    `json:"type_id,omitempty"`
+}
+
+func (s Asset) String() string {
+	return Stringify(s)
+}
+
+// AssetsResponse holds a list of assets.
+type AssetsResponse []*Asset
+
+// GetCharacterAssets returns a page of the assets of a character.
+func (e *AssetsEndpoint) GetCharacterAssets(ctx context.Context, cid int, opt *ListOptions) (AssetsResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_assets", cid)
+	return e.getAssets(ctx, u, opt)
+}
+
+// GetCorporationAssets returns a page of the assets of a corporation.
+func (e *AssetsEndpoint) GetCorporationAssets(ctx context.Context, cid int, opt *ListOptions) (AssetsResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_assets", cid)
+	return e.getAssets(ctx, u, opt)
+}
+
+// ListAllCharacterAssets returns the assets on all pages of a character.
+func (e *AssetsEndpoint) ListAllCharacterAssets(ctx context.Context, cid int) (AssetsResponse, error) {
+	return e.listAllAssets(ctx, func(ctx context.Context, page int) (interface{}, *Response, error) {
+		return e.GetCharacterAssets(ctx, cid, &ListOptions{Page: page})
+	})
+}
+
+// ListAllCorporationAssets returns the assets on all pages of a corporation.
+func (e *AssetsEndpoint) ListAllCorporationAssets(ctx context.Context, cid int) (AssetsResponse, error) {
+	return e.listAllAssets(ctx, func(ctx context.Context, page int) (interface{}, *Response, error) {
+		return e.GetCorporationAssets(ctx, cid, &ListOptions{Page: page})
+	})
+}
+
+func (e *AssetsEndpoint) getAssets(ctx context.Context, u string, opt *ListOptions) (AssetsResponse, *Response, error) {
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var assetsResponse AssetsResponse
+	resp, err := e.api.Do(ctx, req, &assetsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return assetsResponse, resp, nil
+}
+
+func (e *AssetsEndpoint) listAllAssets(ctx context.Context, fetch PageFunc) (AssetsResponse, error) {
+	it := e.api.NewPageIterator(ctx, fetch)
+	defer it.Close()
+
+	var assets AssetsResponse
+	for it.Next() {
+		assets = append(assets, it.Item().(*Asset))
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+// Position holds coordinates in space.
+type Position struct {
+	X *float64 `json:"x,omitempty"`
+	Y *float64 `json:"y,omitempty"`
+	Z *float64 `json:"z,omitempty"`
+}
+
+// AssetLocation holds the position of an item in space.
+type AssetLocation struct {
+	ItemID   *int64    `json:"item_id,omitempty"`
+	Position *Position `json:"position,omitempty"`
+}
+
+func (s AssetLocation) String() string {
+	return Stringify(s)
+}
+
+// AssetLocationsResponse holds a list of asset locations.
+type AssetLocationsResponse []*AssetLocation
+
+// GetCharacterAssetLocations returns the positions of items owned by a
+// character. Only items in space and ships in hangars have positions.
+func (e *AssetsEndpoint) GetCharacterAssetLocations(ctx context.Context, cid int, ids []int64) (AssetLocationsResponse, *Response, error) {
+	u := e.api.route("post_characters_character_id_assets_locations", cid)
+	return e.getAssetLocations(ctx, u, ids)
+}
+
+// GetCorporationAssetLocations returns the positions of items owned by a
+// corporation. Only items in space and ships in hangars have positions.
+func (e *AssetsEndpoint) GetCorporationAssetLocations(ctx context.Context, cid int, ids []int64) (AssetLocationsResponse, *Response, error) {
+	u := e.api.route("post_corporations_corporation_id_assets_locations", cid)
+	return e.getAssetLocations(ctx, u, ids)
+}
+
+func (e *AssetsEndpoint) getAssetLocations(ctx context.Context, u string, ids []int64) (AssetLocationsResponse, *Response, error) {
+	if len(ids) == 0 {
+		return nil, nil, ErrNoAssetIDs
+	}
+
+	var assetLocationsResponse AssetLocationsResponse
+
+	var resp *Response
+	for _, chunk := range chunkIDs(ids, MaxAssetIDs) {
+		req, err := e.api.NewRequest("POST", u, chunk)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var locations AssetLocationsResponse
+		resp, err = e.api.Do(ctx, req, &locations)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		assetLocationsResponse = append(assetLocationsResponse, locations...)
+	}
+
+	return assetLocationsResponse, resp, nil
+}
+
+// AssetName holds the name of an item.
+type AssetName struct {
+	ItemID *int64  `json:"item_id,omitempty"`
+	Name   *string `json:"name,omitempty"`
+}
+
+func (s AssetName) String() string {
+	return Stringify(s)
+}
+
+// AssetNamesResponse holds a list of asset names.
+type AssetNamesResponse []*AssetName
+
+// GetCharacterAssetNames returns the names of items owned by a character.
+// Only singleton items, such as ships and containers, can have names.
+func (e *AssetsEndpoint) GetCharacterAssetNames(ctx context.Context, cid int, ids []int64) (AssetNamesResponse, *Response, error) {
+	u := e.api.route("post_characters_character_id_assets_names", cid)
+	return e.getAssetNames(ctx, u, ids)
+}
+
+// GetCorporationAssetNames returns the names of items owned by a corporation.
+// Only singleton items, such as ships and containers, can have names.
+func (e *AssetsEndpoint) GetCorporationAssetNames(ctx context.Context, cid int, ids []int64) (AssetNamesResponse, *Response, error) {
+	u := e.api.route("post_corporations_corporation_id_assets_names", cid)
+	return e.getAssetNames(ctx, u, ids)
+}
+
+func (e *AssetsEndpoint) getAssetNames(ctx context.Context, u string, ids []int64) (AssetNamesResponse, *Response, error) {
+	if len(ids) == 0 {
+		return nil, nil, ErrNoAssetIDs
+	}
+
+	var assetNamesResponse AssetNamesResponse
+
+	var resp *Response
+	for _, chunk := range chunkIDs(ids, MaxAssetIDs) {
+		req, err := e.api.NewRequest("POST", u, chunk)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var names AssetNamesResponse
+		resp, err = e.api.Do(ctx, req, &names)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		assetNamesResponse = append(assetNamesResponse, names...)
+	}
+
+	return assetNamesResponse, resp, nil
+}
+
+// chunkIDs splits ids into chunks of at most n IDs.
+func chunkIDs(ids []int64, n int) [][]int64 {
+	var chunks [][]int64
+	for len(ids) > n {
+		chunks = append(chunks, ids[:n])
+		ids = ids[n:]
+	}
+
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+
+	return chunks
+}
@@ -0,0 +1,196 @@
+package esi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestAssetsEndpoint_GetCharacterAssets(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/characters/42/assets/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "2"})
+		fmt.Fprint(w, `
+			[
+				{
+					"is_singleton": true,
+					"item_id": 1000000016835,
+					"location_flag": "Hangar",
+					"location_id": 60002959,
+					"location_type": "station",
+					"quantity": 1,
+					"type_id": 3516
+				}
+			]
+		`)
+	})
+
+	assets, _, err := client.Assets.GetCharacterAssets(context.Background(), 42, &ListOptions{Page: 2})
+	if err != nil {
+		t.Errorf("Assets.GetCharacterAssets returned error: %v", err)
+	}
+
+	want := AssetsResponse{
+		{
+			IsSingleton:  Bool(true),
+			ItemID:       Int64(1000000016835),
+			LocationFlag: String("Hangar"),
+			LocationID:   Int64(60002959),
+			LocationType: String("station"),
+			Quantity:     Int(1),
+			TypeID:       Int(3516),
+		},
+	}
+	if !reflect.DeepEqual(assets, want) {
+		t.Errorf("Assets.GetCharacterAssets returned %+v, want %+v", assets, want)
+	}
+}
+
+func TestAssetsEndpoint_ListAllCorporationAssets(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/corporations/42/assets/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		w.Header().Set("X-Pages", "3")
+		fmt.Fprintf(w, `[{"item_id": %d}]`, page)
+	})
+
+	assets, err := client.Assets.ListAllCorporationAssets(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Assets.ListAllCorporationAssets returned error: %v", err)
+	}
+
+	want := AssetsResponse{{ItemID: Int64(1)}, {ItemID: Int64(2)}, {ItemID: Int64(3)}}
+	if !reflect.DeepEqual(assets, want) {
+		t.Errorf("Assets.ListAllCorporationAssets returned %+v, want %+v", assets, want)
+	}
+}
+
+func TestAssetsEndpoint_GetCharacterAssetNames(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/v1/characters/42/assets/names/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		calls++
+
+		var ids []int64
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ids) > MaxAssetIDs {
+			t.Errorf("request has %d IDs, want at most %d", len(ids), MaxAssetIDs)
+		}
+
+		fmt.Fprintf(w, `[{"item_id": %d, "name": "Box"}]`, ids[0])
+	})
+
+	ids := make([]int64, 2500)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+
+	names, _, err := client.Assets.GetCharacterAssetNames(context.Background(), 42, ids)
+	if err != nil {
+		t.Fatalf("Assets.GetCharacterAssetNames returned error: %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("Assets.GetCharacterAssetNames made %d calls, want 3", calls)
+	}
+
+	want := AssetNamesResponse{
+		{ItemID: Int64(1), Name: String("Box")},
+		{ItemID: Int64(1001), Name: String("Box")},
+		{ItemID: Int64(2001), Name: String("Box")},
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Assets.GetCharacterAssetNames returned %+v, want %+v", names, want)
+	}
+}
+
+func TestAssetsEndpoint_noIDs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	_, _, err := client.Assets.GetCharacterAssetNames(context.Background(), 42, nil)
+	if !errors.Is(err, ErrNoAssetIDs) || !errors.Is(err, ErrBadRequest) {
+		t.Errorf("Assets.GetCharacterAssetNames returned error %v, want ErrNoAssetIDs", err)
+	}
+
+	_, _, err = client.Assets.GetCorporationAssetLocations(context.Background(), 42, []int64{})
+	if !errors.Is(err, ErrNoAssetIDs) {
+		t.Errorf("Assets.GetCorporationAssetLocations returned error %v, want ErrNoAssetIDs", err)
+	}
+}
+
+func TestAssetsEndpoint_GetCorporationAssetLocations(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/corporations/42/assets/locations/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, "[1000000016835]\n")
+		fmt.Fprint(w, `[{"item_id": 1000000016835, "position": {"x": 1.2, "y": 2.3, "z": -3.4}}]`)
+	})
+
+	locations, _, err := client.Assets.GetCorporationAssetLocations(context.Background(), 42, []int64{1000000016835})
+	if err != nil {
+		t.Errorf("Assets.GetCorporationAssetLocations returned error: %v", err)
+	}
+
+	want := AssetLocationsResponse{
+		{
+			ItemID:   Int64(1000000016835),
+			Position: &Position{X: Float64(1.2), Y: Float64(2.3), Z: Float64(-3.4)},
+		},
+	}
+	if !reflect.DeepEqual(locations, want) {
+		t.Errorf("Assets.GetCorporationAssetLocations returned %+v, want %+v", locations, want)
+	}
+}
+
+func TestAssetsEndpoint_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/characters/42/assets/locations/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "forbidden"}`, http.StatusForbidden)
+	})
+
+	if _, _, err := client.Assets.GetCharacterAssetLocations(context.Background(), 42, []int64{1}); err == nil {
+		t.Errorf("Assets.GetCharacterAssetLocations returned no error")
+	}
+}
+
+func TestChunkIDs(t *testing.T) {
+	for _, tt := range []struct {
+		ids  []int64
+		want [][]int64
+	}{
+		{nil, nil},
+		{[]int64{1, 2}, [][]int64{{1, 2}}},
+		{[]int64{1, 2, 3}, [][]int64{{1, 2}, {3}}},
+		{[]int64{1, 2, 3, 4}, [][]int64{{1, 2}, {3, 4}}},
+	} {
+		if got := chunkIDs(tt.ids, 2); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("chunkIDs(%v, 2) = %v, want %v", tt.ids, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package esi
+
+import "sort"
+
+// An AssetNode is a node in an AssetTree. Nodes either hold an asset or, at
+// the root of the tree, a location that is not itself an asset, such as a
+// station or a solar system.
+type AssetNode struct {
+	// ID is the item ID of the asset, or the location ID of a root.
+	ID int64
+
+	// Asset is the asset held by the node. It is nil for roots.
+	Asset *Asset
+
+	// LocationType is the type of a root location as reported by ESI;
+	// "station", "solar_system", "item" or "other". Structures are of type
+	// "item". It is empty for assets.
+	LocationType string
+
+	// Name is the name of the asset, if set with AssetTree.SetNames.
+	Name string
+
+	Parent   *AssetNode
+	Children []*AssetNode
+}
+
+// Flag returns the location flag of the asset, such as "Hangar" or "Cargo",
+// which tells where in its parent the asset is.
+func (n *AssetNode) Flag() string {
+	if n.Asset == nil || n.Asset.LocationFlag == nil {
+		return ""
+	}
+
+	return *n.Asset.LocationFlag
+}
+
+// Path returns the nodes from the root of the tree down to n.
+func (n *AssetNode) Path() []*AssetNode {
+	var path []*AssetNode
+	for ; n != nil; n = n.Parent {
+		path = append(path, n)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// Root returns the root location of n.
+func (n *AssetNode) Root() *AssetNode {
+	for n.Parent != nil {
+		n = n.Parent
+	}
+
+	return n
+}
+
+// Walk calls fn for n and its descendants, depth first. If fn returns false,
+// the children of the node are skipped.
+func (n *AssetNode) Walk(fn func(*AssetNode) bool) {
+	if !fn(n) {
+		return
+	}
+
+	for _, c := range n.Children {
+		c.Walk(fn)
+	}
+}
+
+// An AssetRollup holds totals over the descendants of a node.
+type AssetRollup struct {
+	// Items is the number of assets.
+	Items int
+
+	// Quantity is the sum of the quantities of the assets.
+	Quantity int64
+
+	// Types maps type IDs to their total quantity.
+	Types map[int]int64
+}
+
+// Rollup returns the totals over all descendants of n, not including n
+// itself.
+func (n *AssetNode) Rollup() AssetRollup {
+	r := AssetRollup{Types: make(map[int]int64)}
+
+	for _, c := range n.Children {
+		c.Walk(func(a *AssetNode) bool {
+			var qty int64
+			if a.Asset.Quantity != nil {
+				qty = int64(*a.Asset.Quantity)
+			}
+
+			r.Items++
+			r.Quantity += qty
+			if a.Asset.TypeID != nil {
+				r.Types[*a.Asset.TypeID] += qty
+			}
+
+			return true
+		})
+	}
+
+	return r
+}
+
+// An AssetTree arranges a flat list of assets into a tree. Assets located in
+// other assets, such as items in a container in the cargo hold of a ship, are
+// children of those assets. Assets located elsewhere are children of a root
+// node for their location.
+type AssetTree struct {
+	// Roots holds the root locations, sorted by ID.
+	Roots []*AssetNode
+
+	nodes map[int64]*AssetNode
+	roots map[int64]*AssetNode
+}
+
+// NewAssetTree builds the tree of the given assets, such as those returned by
+// AssetsEndpoint.ListAllCharacterAssets. Assets without an item ID are
+// ignored.
+func NewAssetTree(assets AssetsResponse) *AssetTree {
+	t := &AssetTree{
+		nodes: make(map[int64]*AssetNode),
+		roots: make(map[int64]*AssetNode),
+	}
+
+	for _, a := range assets {
+		if a.ItemID == nil {
+			continue
+		}
+
+		t.nodes[*a.ItemID] = &AssetNode{ID: *a.ItemID, Asset: a}
+	}
+
+	for _, a := range assets {
+		if a.ItemID == nil {
+			continue
+		}
+
+		n := t.nodes[*a.ItemID]
+
+		var lid int64
+		if a.LocationID != nil {
+			lid = *a.LocationID
+		}
+
+		parent, ok := t.nodes[lid]
+		if !ok || parent.descendantOf(n) {
+			parent = t.root(lid, a)
+		}
+
+		n.Parent = parent
+		parent.Children = append(parent.Children, n)
+	}
+
+	for _, n := range t.nodes {
+		sortNodes(n.Children)
+	}
+
+	for _, n := range t.roots {
+		sortNodes(n.Children)
+		t.Roots = append(t.Roots, n)
+	}
+
+	sortNodes(t.Roots)
+
+	return t
+}
+
+// root returns the root node of the location of a, creating it if needed.
+func (t *AssetTree) root(lid int64, a *Asset) *AssetNode {
+	if n, ok := t.roots[lid]; ok {
+		return n
+	}
+
+	n := &AssetNode{ID: lid}
+	if a.LocationType != nil {
+		n.LocationType = *a.LocationType
+	}
+
+	t.roots[lid] = n
+
+	return n
+}
+
+// descendantOf reports whether n is a or one of its descendants, guarding
+// against assets that would form a cycle.
+func (n *AssetNode) descendantOf(a *AssetNode) bool {
+	for ; n != nil; n = n.Parent {
+		if n == a {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortNodes(nodes []*AssetNode) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+}
+
+// Node returns the node of an asset or root location.
+func (t *AssetTree) Node(id int64) (*AssetNode, bool) {
+	if n, ok := t.nodes[id]; ok {
+		return n, true
+	}
+
+	n, ok := t.roots[id]
+
+	return n, ok
+}
+
+// ItemIDs returns the item IDs of all assets in the tree, sorted. To look up
+// asset names, use SingletonIDs instead.
+func (t *AssetTree) ItemIDs() []int64 {
+	ids := make([]int64, 0, len(t.nodes))
+	for id := range t.nodes {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// SingletonIDs returns the item IDs of the singleton assets in the tree,
+// sorted. Only singleton items, such as assembled ships and containers, can
+// be named, and ESI rejects name lookups for other items. The IDs can be
+// passed to AssetsEndpoint.GetCharacterAssetNames, which splits them into
+// requests of at most MaxAssetIDs.
+func (t *AssetTree) SingletonIDs() []int64 {
+	var ids []int64
+	for id, n := range t.nodes {
+		if n.Asset.IsSingleton != nil && *n.Asset.IsSingleton {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// SetNames sets the names of the assets in the tree.
+func (t *AssetTree) SetNames(names AssetNamesResponse) {
+	for _, name := range names {
+		if name.ItemID == nil || name.Name == nil {
+			continue
+		}
+
+		if n, ok := t.nodes[*name.ItemID]; ok {
+			n.Name = *name.Name
+		}
+	}
+}
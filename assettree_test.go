@@ -0,0 +1,181 @@
+package esi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func asset(id, location int64, flag, locationType string, typeID, qty int) *Asset {
+	return &Asset{
+		ItemID:       Int64(id),
+		LocationID:   Int64(location),
+		LocationFlag: String(flag),
+		LocationType: String(locationType),
+		TypeID:       Int(typeID),
+		Quantity:     Int(qty),
+	}
+}
+
+func testAssetTree() *AssetTree {
+	return NewAssetTree(AssetsResponse{
+		// a ship in a station hangar with a container in its cargo hold
+		asset(100, 60003760, "Hangar", "station", 587, 1),
+		asset(101, 100, "Cargo", "item", 3467, 1),
+		asset(102, 101, "Unlocked", "item", 34, 5000),
+		asset(103, 100, "HiSlot0", "item", 3082, 1),
+
+		// loose items in the same hangar
+		asset(104, 60003760, "Hangar", "station", 34, 1000),
+
+		// a structure that is not an asset itself
+		asset(200, 1021975535893, "Hangar", "item", 35, 200),
+
+		// a deployed item in space
+		asset(300, 30000142, "AutoFit", "solar_system", 33475, 1),
+	})
+}
+
+func nodeIDs(nodes []*AssetNode) []int64 {
+	var ids []int64
+	for _, n := range nodes {
+		ids = append(ids, n.ID)
+	}
+
+	return ids
+}
+
+func TestNewAssetTree(t *testing.T) {
+	tree := testAssetTree()
+
+	if want := []int64{30000142, 60003760, 1021975535893}; !reflect.DeepEqual(nodeIDs(tree.Roots), want) {
+		t.Fatalf("Roots = %v, want %v", nodeIDs(tree.Roots), want)
+	}
+
+	for i, want := range []string{"solar_system", "station", "item"} {
+		if got := tree.Roots[i].LocationType; got != want {
+			t.Errorf("Roots[%d].LocationType = %q, want %q", i, got, want)
+		}
+	}
+
+	station, _ := tree.Node(60003760)
+	if want := []int64{100, 104}; !reflect.DeepEqual(nodeIDs(station.Children), want) {
+		t.Errorf("station children = %v, want %v", nodeIDs(station.Children), want)
+	}
+
+	ship, _ := tree.Node(100)
+	if want := []int64{101, 103}; !reflect.DeepEqual(nodeIDs(ship.Children), want) {
+		t.Errorf("ship children = %v, want %v", nodeIDs(ship.Children), want)
+	}
+
+	item, ok := tree.Node(102)
+	if !ok {
+		t.Fatalf("Node(102) not found")
+	}
+
+	if want := []int64{60003760, 100, 101, 102}; !reflect.DeepEqual(nodeIDs(item.Path()), want) {
+		t.Errorf("Path() = %v, want %v", nodeIDs(item.Path()), want)
+	}
+
+	if item.Root() != station {
+		t.Errorf("Root() = %v, want station", item.Root().ID)
+	}
+
+	if item.Flag() != "Unlocked" {
+		t.Errorf("Flag() = %q, want %q", item.Flag(), "Unlocked")
+	}
+
+	if _, ok := tree.Node(999); ok {
+		t.Errorf("Node(999) found")
+	}
+}
+
+func TestNewAssetTree_cycle(t *testing.T) {
+	tree := NewAssetTree(AssetsResponse{
+		asset(1, 2, "Cargo", "item", 1, 1),
+		asset(2, 1, "Cargo", "item", 1, 1),
+	})
+
+	// one of the items has to become a root location
+	if len(tree.Roots) != 1 {
+		t.Fatalf("Roots = %v, want a single root", nodeIDs(tree.Roots))
+	}
+
+	var n int
+	for _, r := range tree.Roots {
+		r.Walk(func(*AssetNode) bool { n++; return true })
+	}
+
+	if n != 3 {
+		t.Errorf("walked %d nodes, want 3", n)
+	}
+}
+
+func TestAssetNode_Rollup(t *testing.T) {
+	tree := testAssetTree()
+
+	station, _ := tree.Node(60003760)
+	r := station.Rollup()
+
+	want := AssetRollup{
+		Items:    5,
+		Quantity: 6003,
+		Types:    map[int]int64{587: 1, 3467: 1, 34: 6000, 3082: 1},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("Rollup() = %+v, want %+v", r, want)
+	}
+
+	container, _ := tree.Node(101)
+	if r := container.Rollup(); r.Items != 1 || r.Quantity != 5000 {
+		t.Errorf("container Rollup() = %+v", r)
+	}
+}
+
+func TestAssetNode_Walk_skip(t *testing.T) {
+	station, _ := testAssetTree().Node(60003760)
+
+	var visited []int64
+	station.Walk(func(n *AssetNode) bool {
+		visited = append(visited, n.ID)
+		return n.ID != 100
+	})
+
+	if want := []int64{60003760, 100, 104}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("visited %v, want %v", visited, want)
+	}
+}
+
+func TestAssetTree_SetNames(t *testing.T) {
+	tree := testAssetTree()
+
+	tree.SetNames(AssetNamesResponse{
+		{ItemID: Int64(100), Name: String("Rifter")},
+		{ItemID: Int64(999), Name: String("Unknown")},
+		{ItemID: Int64(101)},
+	})
+
+	ship, _ := tree.Node(100)
+	if ship.Name != "Rifter" {
+		t.Errorf("Name = %q, want %q", ship.Name, "Rifter")
+	}
+
+	if want := []int64{100, 101, 102, 103, 104, 200, 300}; !reflect.DeepEqual(tree.ItemIDs(), want) {
+		t.Errorf("ItemIDs() = %v, want %v", tree.ItemIDs(), want)
+	}
+}
+
+func TestAssetTree_SingletonIDs(t *testing.T) {
+	tree := testAssetTree()
+
+	for _, id := range []int64{100, 101} {
+		n, _ := tree.Node(id)
+		n.Asset.IsSingleton = Bool(true)
+	}
+
+	n, _ := tree.Node(102)
+	n.Asset.IsSingleton = Bool(false)
+
+	if want := []int64{100, 101}; !reflect.DeepEqual(tree.SingletonIDs(), want) {
+		t.Errorf("SingletonIDs() = %v, want %v", tree.SingletonIDs(), want)
+	}
+}
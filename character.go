@@ -0,0 +1,20 @@
+package esi
+
+import "context"
+
+type characterKey struct{}
+
+// WithCharacter returns a copy of ctx that makes requests carried out with it
+// act on behalf of the character with ID cid. The HTTP client of the Client
+// is responsible for authorizing the requests as that character, for example
+// with a token registry from the sso package.
+func WithCharacter(ctx context.Context, cid int) context.Context {
+	return context.WithValue(ctx, characterKey{}, cid)
+}
+
+// CharacterFromContext returns the ID of the character set in ctx with
+// WithCharacter.
+func CharacterFromContext(ctx context.Context) (int, bool) {
+	cid, ok := ctx.Value(characterKey{}).(int)
+	return cid, ok
+}
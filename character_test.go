@@ -0,0 +1,46 @@
+package esi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestCharacterFromContext(t *testing.T) {
+	if _, ok := CharacterFromContext(context.Background()); ok {
+		t.Errorf("CharacterFromContext returned true for empty context")
+	}
+
+	cid, ok := CharacterFromContext(WithCharacter(context.Background(), 42))
+	if !ok || cid != 42 {
+		t.Errorf("CharacterFromContext returned %v, %v, want 42, true", cid, ok)
+	}
+}
+
+func TestDo_characterInRequestContext(t *testing.T) {
+	var got int
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.client = &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		got, _ = CharacterFromContext(r.Context())
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+
+	mux.HandleFunc("/v1/fleets/42/", func(w http.ResponseWriter, r *http.Request) {})
+
+	if _, _, err := client.Fleets.Get(WithCharacter(context.Background(), 43), 42); err != nil {
+		t.Fatalf("Fleets.Get returned error: %v", err)
+	}
+
+	if got != 43 {
+		t.Errorf("character in request context is %d, want 43", got)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -1,9 +1,6 @@
 package esi
 
-import (
-	"context"
-	"fmt"
-)
+import "context"
 
 // CharactersEndpoint handles communication with the characters related methods
 // of the ESI API.
@@ -30,7 +27,7 @@ func (s CharacterPublicInfo) String() string {
 
 // GetCharacter returns public information about a character.
 func (e *CharactersEndpoint) GetCharacter(ctx context.Context, cid int) (*CharacterPublicInfo, *Response, error) {
-	u := fmt.Sprintf("v1/characters/%d/", cid)
+	u := e.api.route("get_characters_character_id", cid)
 
 	req, err := e.api.NewRequest("GET", u, nil)
 	if err != nil {
@@ -45,3 +42,425 @@ func (e *CharactersEndpoint) GetCharacter(ctx context.Context, cid int) (*Charac
 
 	return characterPublicInfo, resp, nil
 }
+
+// CharacterAffiliation holds the corporation, alliance and faction a
+// character is affiliated with.
+type CharacterAffiliation struct {
+	AllianceID    *int `json:"alliance_id,omitempty"`
+	CharacterID   *int `json:"character_id,omitempty"`
+	CorporationID *int `json:"corporation_id,omitempty"`
+	FactionID     *int `json:"faction_id,omitempty"`
+}
+
+func (s CharacterAffiliation) String() string {
+	return Stringify(s)
+}
+
+// CharacterAffiliationsResponse holds affiliations of a number of characters.
+type CharacterAffiliationsResponse []*CharacterAffiliation
+
+// GetAffiliations returns the affiliations of a set of characters.
+func (e *CharactersEndpoint) GetAffiliations(ctx context.Context, cids []int) (CharacterAffiliationsResponse, *Response, error) {
+	u := e.api.route("post_characters_affiliation")
+
+	req, err := e.api.NewRequest("POST", u, cids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var characterAffiliationsResponse CharacterAffiliationsResponse
+	resp, err := e.api.Do(ctx, req, &characterAffiliationsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return characterAffiliationsResponse, resp, nil
+}
+
+// CorporationHistoryEntry holds a single entry of a character's corporation
+// history.
+type CorporationHistoryEntry struct {
+	CorporationID *int       `json:"corporation_id,omitempty"`
+	IsDeleted     *bool      `json:"is_deleted,omitempty"`
+	RecordID      *int       `json:"record_id,omitempty"`
+	StartDate     *Timestamp `json:"start_date,omitempty"`
+}
+
+func (s CorporationHistoryEntry) String() string {
+	return Stringify(s)
+}
+
+// CorporationHistoryResponse holds the corporation history of a character.
+type CorporationHistoryResponse []*CorporationHistoryEntry
+
+// GetCorporationHistory returns the history of corporations a character has
+// been a member of.
+func (e *CharactersEndpoint) GetCorporationHistory(ctx context.Context, cid int) (CorporationHistoryResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_corporationhistory", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var corporationHistoryResponse CorporationHistoryResponse
+	resp, err := e.api.Do(ctx, req, &corporationHistoryResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return corporationHistoryResponse, resp, nil
+}
+
+// CharacterPortrait holds the URLs of a character's portrait in various
+// sizes.
+type CharacterPortrait struct {
+	Px64x64   *string `json:"px64x64,omitempty"`
+	Px128x128 *string `json:"px128x128,omitempty"`
+	Px256x256 *string `json:"px256x256,omitempty"`
+	Px512x512 *string `json:"px512x512,omitempty"`
+}
+
+func (s CharacterPortrait) String() string {
+	return Stringify(s)
+}
+
+// GetPortrait returns the portrait URLs of a character.
+func (e *CharactersEndpoint) GetPortrait(ctx context.Context, cid int) (*CharacterPortrait, *Response, error) {
+	u := e.api.route("get_characters_character_id_portrait", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	characterPortrait := new(CharacterPortrait)
+	resp, err := e.api.Do(ctx, req, characterPortrait)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return characterPortrait, resp, nil
+}
+
+// CharacterRoles holds the corporation roles of a character.
+type CharacterRoles struct {
+	Roles        []string `json:"roles,omitempty"`
+	RolesAtBase  []string `json:"roles_at_base,omitempty"`
+	RolesAtHQ    []string `json:"roles_at_hq,omitempty"`
+	RolesAtOther []string `json:"roles_at_other,omitempty"`
+}
+
+func (s CharacterRoles) String() string {
+	return Stringify(s)
+}
+
+// GetRoles returns the corporation roles of a character.
+func (e *CharactersEndpoint) GetRoles(ctx context.Context, cid int) (*CharacterRoles, *Response, error) {
+	u := e.api.route("get_characters_character_id_roles", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	characterRoles := new(CharacterRoles)
+	resp, err := e.api.Do(ctx, req, characterRoles)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return characterRoles, resp, nil
+}
+
+// CharacterTitle holds a corporation title held by a character.
+type CharacterTitle struct {
+	Name    *string `json:"name,omitempty"`
+	TitleID *int    `json:"title_id,omitempty"`
+}
+
+func (s CharacterTitle) String() string {
+	return Stringify(s)
+}
+
+// CharacterTitlesResponse holds the corporation titles of a character.
+type CharacterTitlesResponse []*CharacterTitle
+
+// GetTitles returns the corporation titles of a character.
+func (e *CharactersEndpoint) GetTitles(ctx context.Context, cid int) (CharacterTitlesResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_titles", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var characterTitlesResponse CharacterTitlesResponse
+	resp, err := e.api.Do(ctx, req, &characterTitlesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return characterTitlesResponse, resp, nil
+}
+
+// Standing holds the standing of an agent, NPC corporation or faction towards
+// a character.
+type Standing struct {
+	FromID   *int     `json:"from_id,omitempty"`
+	FromType *string  `json:"from_type,omitempty"`
+	Standing *float64 `json:"standing,omitempty"`
+}
+
+func (s Standing) String() string {
+	return Stringify(s)
+}
+
+// StandingsResponse holds a list of standings.
+type StandingsResponse []*Standing
+
+// GetStandings returns the standings of agents, NPC corporations and factions
+// towards a character.
+func (e *CharactersEndpoint) GetStandings(ctx context.Context, cid int) (StandingsResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_standings", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var standingsResponse StandingsResponse
+	resp, err := e.api.Do(ctx, req, &standingsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return standingsResponse, resp, nil
+}
+
+// MedalGraphic holds a graphic layer of a medal.
+type MedalGraphic struct {
+	Color   *int    `json:"color,omitempty"`
+	Graphic *string `json:"graphic,omitempty"`
+	Layer   *int    `json:"layer,omitempty"`
+	Part    *int    `json:"part,omitempty"`
+}
+
+func (s MedalGraphic) String() string {
+	return Stringify(s)
+}
+
+// CharacterMedal holds a medal awarded to a character.
+type CharacterMedal struct {
+	CorporationID *int            `json:"corporation_id,omitempty"`
+	Date          *Timestamp      `json:"date,omitempty"`
+	Description   *string         `json:"description,omitempty"`
+	Graphics      []*MedalGraphic `json:"graphics,omitempty"`
+	IssuerID      *int            `json:"issuer_id,omitempty"`
+	MedalID       *int            `json:"medal_id,omitempty"`
+	Reason        *string         `json:"reason,omitempty"`
+	Status        *string         `json:"status,omitempty"`
+	Title         *string         `json:"title,omitempty"`
+}
+
+func (s CharacterMedal) String() string {
+	return Stringify(s)
+}
+
+// CharacterMedalsResponse holds the medals of a character.
+type CharacterMedalsResponse []*CharacterMedal
+
+// GetMedals returns the medals awarded to a character.
+func (e *CharactersEndpoint) GetMedals(ctx context.Context, cid int) (CharacterMedalsResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_medals", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var characterMedalsResponse CharacterMedalsResponse
+	resp, err := e.api.Do(ctx, req, &characterMedalsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return characterMedalsResponse, resp, nil
+}
+
+// AgentResearch holds details of a research agent a character is working
+// with.
+type AgentResearch struct {
+	AgentID         *int       `json:"agent_id,omitempty"`
+	PointsPerDay    *float64   `json:"points_per_day,omitempty"`
+	RemainderPoints *float64   `json:"remainder_points,omitempty"`
+	SkillTypeID     *int       `json:"skill_type_id,omitempty"`
+	StartedAt       *Timestamp `json:"started_at,omitempty"`
+}
+
+func (s AgentResearch) String() string {
+	return Stringify(s)
+}
+
+// AgentsResearchResponse holds the research agents of a character.
+type AgentsResearchResponse []*AgentResearch
+
+// GetAgentsResearch returns the research agents a character is working with.
+func (e *CharactersEndpoint) GetAgentsResearch(ctx context.Context, cid int) (AgentsResearchResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_agents_research", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var agentsResearchResponse AgentsResearchResponse
+	resp, err := e.api.Do(ctx, req, &agentsResearchResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return agentsResearchResponse, resp, nil
+}
+
+// Blueprint holds details of a blueprint.
+type Blueprint struct {
+	ItemID             *int64  `json:"item_id,omitempty"`
+	LocationFlag       *string `json:"location_flag,omitempty"`
+	LocationID         *int64  `json:"location_id,omitempty"`
+	MaterialEfficiency *int    `json:"material_efficiency,omitempty"`
+	Quantity           *int    `json:"quantity,omitempty"`
+	Runs               *int    `json:"runs,omitempty"`
+	TimeEfficiency     *int    `json:"time_efficiency,omitempty"`
+	TypeID             *int    `json:"type_id,omitempty"`
+}
+
+func (s Blueprint) String() string {
+	return Stringify(s)
+}
+
+// BlueprintsResponse holds a list of blueprints.
+type BlueprintsResponse []*Blueprint
+
+// GetBlueprints returns a page of the blueprints of a character.
+func (e *CharactersEndpoint) GetBlueprints(ctx context.Context, cid int, opt *ListOptions) (BlueprintsResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_blueprints", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var blueprintsResponse BlueprintsResponse
+	resp, err := e.api.Do(ctx, req, &blueprintsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return blueprintsResponse, resp, nil
+}
+
+// CharacterFatigue holds the jump fatigue of a character.
+type CharacterFatigue struct {
+	JumpFatigueExpireDate *Timestamp `json:"jump_fatigue_expire_date,omitempty"`
+	LastJumpDate          *Timestamp `json:"last_jump_date,omitempty"`
+	LastUpdateDate        *Timestamp `json:"last_update_date,omitempty"`
+}
+
+func (s CharacterFatigue) String() string {
+	return Stringify(s)
+}
+
+// GetFatigue returns the jump fatigue of a character.
+func (e *CharactersEndpoint) GetFatigue(ctx context.Context, cid int) (*CharacterFatigue, *Response, error) {
+	u := e.api.route("get_characters_character_id_fatigue", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	characterFatigue := new(CharacterFatigue)
+	resp, err := e.api.Do(ctx, req, characterFatigue)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return characterFatigue, resp, nil
+}
+
+// ContactNotification holds a notification about a character being added as
+// a contact.
+type ContactNotification struct {
+	Message           *string    `json:"message,omitempty"`
+	NotificationID    *int       `json:"notification_id,omitempty"`
+	SendDate          *Timestamp `json:"send_date,omitempty"`
+	SenderCharacterID *int       `json:"sender_character_id,omitempty"`
+	StandingLevel     *float64   `json:"standing_level,omitempty"`
+}
+
+func (s ContactNotification) String() string {
+	return Stringify(s)
+}
+
+// ContactNotificationsResponse holds a list of contact notifications.
+type ContactNotificationsResponse []*ContactNotification
+
+// GetContactNotifications returns the notifications about a character being
+// added as a contact by others.
+func (e *CharactersEndpoint) GetContactNotifications(ctx context.Context, cid int) (ContactNotificationsResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_notifications_contacts", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var contactNotificationsResponse ContactNotificationsResponse
+	resp, err := e.api.Do(ctx, req, &contactNotificationsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contactNotificationsResponse, resp, nil
+}
+
+// Notification holds an in-game notification.
+type Notification struct {
+	IsRead         *bool      `json:"is_read,omitempty"`
+	NotificationID *int64     `json:"notification_id,omitempty"`
+	SenderID       *int       `json:"sender_id,omitempty"`
+	SenderType     *string    `json:"sender_type,omitempty"`
+	Text           *string    `json:"text,omitempty"`
+	Timestamp      *Timestamp `json:"timestamp,omitempty"`
+	Type           *string    `json:"type,omitempty"`
+}
+
+func (s Notification) String() string {
+	return Stringify(s)
+}
+
+// NotificationsResponse holds a list of notifications.
+type NotificationsResponse []*Notification
+
+// GetNotifications returns the most recent notifications of a character.
+func (e *CharactersEndpoint) GetNotifications(ctx context.Context, cid int) (NotificationsResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_notifications", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var notificationsResponse NotificationsResponse
+	resp, err := e.api.Do(ctx, req, &notificationsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return notificationsResponse, resp, nil
+}
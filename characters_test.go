@@ -0,0 +1,352 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCharactersEndpoint_GetCharacter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/characters/42/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			{
+				"birthday": "2015-03-24T11:37:00Z",
+				"corporation_id": 109299958,
+				"gender": "male",
+				"name": "CCP Bartender",
+				"race_id": 2
+			}
+		`)
+	})
+
+	info, _, err := client.Characters.GetCharacter(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Characters.GetCharacter returned error: %v", err)
+	}
+
+	want := &CharacterPublicInfo{
+		Birthday:      &Timestamp{time.Date(2015, 3, 24, 11, 37, 0, 0, time.UTC)},
+		CorporationID: Int(109299958),
+		Gender:        String("male"),
+		Name:          String("CCP Bartender"),
+		RaceID:        Int(2),
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("Characters.GetCharacter returned %+v, want %+v", info, want)
+	}
+}
+
+func TestCharactersEndpoint_GetAffiliations(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/characters/affiliation/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, "[95538921,95538922]\n")
+		fmt.Fprint(w, `
+			[
+				{
+					"alliance_id": 434243723,
+					"character_id": 95538921,
+					"corporation_id": 109299958
+				}
+			]
+		`)
+	})
+
+	affiliations, _, err := client.Characters.GetAffiliations(context.Background(), []int{95538921, 95538922})
+	if err != nil {
+		t.Errorf("Characters.GetAffiliations returned error: %v", err)
+	}
+
+	want := CharacterAffiliationsResponse{
+		{
+			AllianceID:    Int(434243723),
+			CharacterID:   Int(95538921),
+			CorporationID: Int(109299958),
+		},
+	}
+	if !reflect.DeepEqual(affiliations, want) {
+		t.Errorf("Characters.GetAffiliations returned %+v, want %+v", affiliations, want)
+	}
+}
+
+func TestCharactersEndpoint_GetCorporationHistory(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/characters/42/corporationhistory/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			[
+				{
+					"corporation_id": 90000001,
+					"is_deleted": true,
+					"record_id": 500,
+					"start_date": "2016-06-26T20:00:00Z"
+				}
+			]
+		`)
+	})
+
+	history, _, err := client.Characters.GetCorporationHistory(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Characters.GetCorporationHistory returned error: %v", err)
+	}
+
+	want := CorporationHistoryResponse{
+		{
+			CorporationID: Int(90000001),
+			IsDeleted:     Bool(true),
+			RecordID:      Int(500),
+			StartDate:     &Timestamp{time.Date(2016, 6, 26, 20, 0, 0, 0, time.UTC)},
+		},
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("Characters.GetCorporationHistory returned %+v, want %+v", history, want)
+	}
+}
+
+func TestCharactersEndpoint_GetRoles(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/characters/42/roles/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"roles": ["Director", "Station_Manager"]}`)
+	})
+
+	roles, _, err := client.Characters.GetRoles(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Characters.GetRoles returned error: %v", err)
+	}
+
+	want := &CharacterRoles{Roles: []string{"Director", "Station_Manager"}}
+	if !reflect.DeepEqual(roles, want) {
+		t.Errorf("Characters.GetRoles returned %+v, want %+v", roles, want)
+	}
+}
+
+func TestCharactersEndpoint_GetMedals(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/characters/42/medals/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			[
+				{
+					"corporation_id": 98000001,
+					"date": "2017-03-16T15:01:45Z",
+					"graphics": [{"color": -1, "graphic": "caldari.1_1", "layer": 0, "part": 1}],
+					"medal_id": 3,
+					"status": "private",
+					"title": "Old Medal"
+				}
+			]
+		`)
+	})
+
+	medals, _, err := client.Characters.GetMedals(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Characters.GetMedals returned error: %v", err)
+	}
+
+	want := CharacterMedalsResponse{
+		{
+			CorporationID: Int(98000001),
+			Date:          &Timestamp{time.Date(2017, 3, 16, 15, 1, 45, 0, time.UTC)},
+			Graphics: []*MedalGraphic{
+				{Color: Int(-1), Graphic: String("caldari.1_1"), Layer: Int(0), Part: Int(1)},
+			},
+			MedalID: Int(3),
+			Status:  String("private"),
+			Title:   String("Old Medal"),
+		},
+	}
+	if !reflect.DeepEqual(medals, want) {
+		t.Errorf("Characters.GetMedals returned %+v, want %+v", medals, want)
+	}
+}
+
+func TestCharactersEndpoint_GetBlueprints(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/characters/42/blueprints/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "2"})
+		w.Header().Set("X-Pages", "2")
+		fmt.Fprint(w, `
+			[
+				{
+					"item_id": 1000000010495,
+					"location_flag": "Hangar",
+					"location_id": 60014719,
+					"material_efficiency": 0,
+					"quantity": 1,
+					"runs": -1,
+					"time_efficiency": 0,
+					"type_id": 691
+				}
+			]
+		`)
+	})
+
+	blueprints, resp, err := client.Characters.GetBlueprints(context.Background(), 42, &ListOptions{Page: 2})
+	if err != nil {
+		t.Errorf("Characters.GetBlueprints returned error: %v", err)
+	}
+
+	want := BlueprintsResponse{
+		{
+			ItemID:             Int64(1000000010495),
+			LocationFlag:       String("Hangar"),
+			LocationID:         Int64(60014719),
+			MaterialEfficiency: Int(0),
+			Quantity:           Int(1),
+			Runs:               Int(-1),
+			TimeEfficiency:     Int(0),
+			TypeID:             Int(691),
+		},
+	}
+	if !reflect.DeepEqual(blueprints, want) {
+		t.Errorf("Characters.GetBlueprints returned %+v, want %+v", blueprints, want)
+	}
+
+	if resp.Pages != 2 || resp.Page != 2 {
+		t.Errorf("unexpected pagination; got page %d of %d", resp.Page, resp.Pages)
+	}
+}
+
+func TestCharactersEndpoint_GetFatigue(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/characters/42/fatigue/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			{
+				"jump_fatigue_expire_date": "2017-07-06T15:47:00Z",
+				"last_jump_date": "2017-07-05T15:47:00Z"
+			}
+		`)
+	})
+
+	fatigue, _, err := client.Characters.GetFatigue(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Characters.GetFatigue returned error: %v", err)
+	}
+
+	want := &CharacterFatigue{
+		JumpFatigueExpireDate: &Timestamp{time.Date(2017, 7, 6, 15, 47, 0, 0, time.UTC)},
+		LastJumpDate:          &Timestamp{time.Date(2017, 7, 5, 15, 47, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(fatigue, want) {
+		t.Errorf("Characters.GetFatigue returned %+v, want %+v", fatigue, want)
+	}
+}
+
+func TestCharactersEndpoint_GetNotifications(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/characters/42/notifications/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			[
+				{
+					"is_read": true,
+					"notification_id": 1,
+					"sender_id": 1000132,
+					"sender_type": "corporation",
+					"text": "amount: 3731016.4000000004",
+					"timestamp": "2017-08-16T10:08:00Z",
+					"type": "InsuranceImpairmentMsg"
+				}
+			]
+		`)
+	})
+
+	notifications, _, err := client.Characters.GetNotifications(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Characters.GetNotifications returned error: %v", err)
+	}
+
+	want := NotificationsResponse{
+		{
+			IsRead:         Bool(true),
+			NotificationID: Int64(1),
+			SenderID:       Int(1000132),
+			SenderType:     String("corporation"),
+			Text:           String("amount: 3731016.4000000004"),
+			Timestamp:      &Timestamp{time.Date(2017, 8, 16, 10, 8, 0, 0, time.UTC)},
+			Type:           String("InsuranceImpairmentMsg"),
+		},
+	}
+	if !reflect.DeepEqual(notifications, want) {
+		t.Errorf("Characters.GetNotifications returned %+v, want %+v", notifications, want)
+	}
+}
+
+func TestCharactersEndpoint_GetPortrait(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/characters/42/portrait/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"px64x64": "https://imageserver.eveonline.com/Character/42_64.jpg"}`)
+	})
+
+	portrait, _, err := client.Characters.GetPortrait(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Characters.GetPortrait returned error: %v", err)
+	}
+
+	want := &CharacterPortrait{Px64x64: String("https://imageserver.eveonline.com/Character/42_64.jpg")}
+	if !reflect.DeepEqual(portrait, want) {
+		t.Errorf("Characters.GetPortrait returned %+v, want %+v", portrait, want)
+	}
+}
+
+func TestCharactersEndpoint_lists(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	for _, path := range []string{
+		"/v1/characters/42/agents_research/",
+		"/v1/characters/42/notifications/contacts/",
+		"/v1/characters/42/standings/",
+		"/v1/characters/42/titles/",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `[{}]`)
+		})
+	}
+
+	ctx := context.Background()
+
+	if v, _, err := client.Characters.GetAgentsResearch(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Characters.GetAgentsResearch returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Characters.GetContactNotifications(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Characters.GetContactNotifications returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Characters.GetStandings(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Characters.GetStandings returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Characters.GetTitles(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Characters.GetTitles returned %v, %v", v, err)
+	}
+}
@@ -0,0 +1,716 @@
+package esi
+
+import "context"
+
+// CorporationsEndpoint handles communication with the corporations related
+// methods of the ESI API.
+type CorporationsEndpoint endpoint
+
+// CorporationPublicInfo holds public information about a corporation.
+type CorporationPublicInfo struct {
+	AllianceID    *int       `json:"alliance_id,omitempty"`
+	CEOID         *int       `json:"ceo_id,omitempty"`
+	CreatorID     *int       `json:"creator_id,omitempty"`
+	DateFounded   *Timestamp `json:"date_founded,omitempty"`
+	Description   *string    `json:"description,omitempty"`
+	FactionID     *int       `json:"faction_id,omitempty"`
+	HomeStationID *int       `json:"home_station_id,omitempty"`
+	MemberCount   *int       `json:"member_count,omitempty"`
+	Name          *string    `json:"name,omitempty"`
+	Shares        *int64     `json:"shares,omitempty"`
+	TaxRate       *float64   `json:"tax_rate,omitempty"`
+	Ticker        *string    `json:"ticker,omitempty"`
+	URL           *string    `json:"url,omitempty"`
+	WarEligible   *bool      `json:"war_eligible,omitempty"`
+}
+
+func (s CorporationPublicInfo) String() string {
+	return Stringify(s)
+}
+
+// Get returns public information about a corporation.
+func (e *CorporationsEndpoint) Get(ctx context.Context, cid int) (*CorporationPublicInfo, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	corporationPublicInfo := new(CorporationPublicInfo)
+	resp, err := e.api.Do(ctx, req, corporationPublicInfo)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return corporationPublicInfo, resp, nil
+}
+
+// AllianceHistoryEntry holds a single entry of a corporation's alliance
+// history.
+type AllianceHistoryEntry struct {
+	AllianceID *int       `json:"alliance_id,omitempty"`
+	IsDeleted  *bool      `json:"is_deleted,omitempty"`
+	RecordID   *int       `json:"record_id,omitempty"`
+	StartDate  *Timestamp `json:"start_date,omitempty"`
+}
+
+func (s AllianceHistoryEntry) String() string {
+	return Stringify(s)
+}
+
+// AllianceHistoryResponse holds the alliance history of a corporation.
+type AllianceHistoryResponse []*AllianceHistoryEntry
+
+// GetAllianceHistory returns the history of alliances a corporation has been
+// a member of.
+func (e *CorporationsEndpoint) GetAllianceHistory(ctx context.Context, cid int) (AllianceHistoryResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_alliancehistory", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var allianceHistoryResponse AllianceHistoryResponse
+	resp, err := e.api.Do(ctx, req, &allianceHistoryResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return allianceHistoryResponse, resp, nil
+}
+
+// GetMembers returns the character IDs of the members of a corporation.
+func (e *CorporationsEndpoint) GetMembers(ctx context.Context, cid int) ([]int, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_members", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// MemberTracking holds tracking information about a corporation member.
+type MemberTracking struct {
+	BaseID      *int       `json:"base_id,omitempty"`
+	CharacterID *int       `json:"character_id,omitempty"`
+	LocationID  *int64     `json:"location_id,omitempty"`
+	LogoffDate  *Timestamp `json:"logoff_date,omitempty"`
+	LogonDate   *Timestamp `json:"logon_date,omitempty"`
+	ShipTypeID  *int       `json:"ship_type_id,omitempty"`
+	StartDate   *Timestamp `json:"start_date,omitempty"`
+}
+
+func (s MemberTracking) String() string {
+	return Stringify(s)
+}
+
+// MemberTrackingResponse holds tracking information about the members of a
+// corporation.
+type MemberTrackingResponse []*MemberTracking
+
+// GetMemberTracking returns tracking information about the members of a
+// corporation.
+func (e *CorporationsEndpoint) GetMemberTracking(ctx context.Context, cid int) (MemberTrackingResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_membertracking", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var memberTrackingResponse MemberTrackingResponse
+	resp, err := e.api.Do(ctx, req, &memberTrackingResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return memberTrackingResponse, resp, nil
+}
+
+// MemberRoles holds the roles of a corporation member.
+type MemberRoles struct {
+	CharacterID           *int     `json:"character_id,omitempty"`
+	GrantableRoles        []string `json:"grantable_roles,omitempty"`
+	GrantableRolesAtBase  []string `json:"grantable_roles_at_base,omitempty"`
+	GrantableRolesAtHQ    []string `json:"grantable_roles_at_hq,omitempty"`
+	GrantableRolesAtOther []string `json:"grantable_roles_at_other,omitempty"`
+	Roles                 []string `json:"roles,omitempty"`
+	RolesAtBase           []string `json:"roles_at_base,omitempty"`
+	RolesAtHQ             []string `json:"roles_at_hq,omitempty"`
+	RolesAtOther          []string `json:"roles_at_other,omitempty"`
+}
+
+func (s MemberRoles) String() string {
+	return Stringify(s)
+}
+
+// MemberRolesResponse holds the roles of the members of a corporation.
+type MemberRolesResponse []*MemberRoles
+
+// GetRoles returns the roles of the members of a corporation.
+func (e *CorporationsEndpoint) GetRoles(ctx context.Context, cid int) (MemberRolesResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_roles", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var memberRolesResponse MemberRolesResponse
+	resp, err := e.api.Do(ctx, req, &memberRolesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return memberRolesResponse, resp, nil
+}
+
+// RoleChange holds a change of the roles of a corporation member.
+type RoleChange struct {
+	ChangedAt   *Timestamp `json:"changed_at,omitempty"`
+	CharacterID *int       `json:"character_id,omitempty"`
+	IssuerID    *int       `json:"issuer_id,omitempty"`
+	NewRoles    []string   `json:"new_roles,omitempty"`
+	OldRoles    []string   `json:"old_roles,omitempty"`
+	RoleType    *string    `json:"role_type,omitempty"`
+}
+
+func (s RoleChange) String() string {
+	return Stringify(s)
+}
+
+// RolesHistoryResponse holds a list of role changes.
+type RolesHistoryResponse []*RoleChange
+
+// GetRolesHistory returns a page of the history of role changes in a
+// corporation.
+func (e *CorporationsEndpoint) GetRolesHistory(ctx context.Context, cid int, opt *ListOptions) (RolesHistoryResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_roles_history", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rolesHistoryResponse RolesHistoryResponse
+	resp, err := e.api.Do(ctx, req, &rolesHistoryResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rolesHistoryResponse, resp, nil
+}
+
+// CorporationTitle holds details of a corporation title.
+type CorporationTitle struct {
+	GrantableRoles        []string `json:"grantable_roles,omitempty"`
+	GrantableRolesAtBase  []string `json:"grantable_roles_at_base,omitempty"`
+	GrantableRolesAtHQ    []string `json:"grantable_roles_at_hq,omitempty"`
+	GrantableRolesAtOther []string `json:"grantable_roles_at_other,omitempty"`
+	Name                  *string  `json:"name,omitempty"`
+	Roles                 []string `json:"roles,omitempty"`
+	RolesAtBase           []string `json:"roles_at_base,omitempty"`
+	RolesAtHQ             []string `json:"roles_at_hq,omitempty"`
+	RolesAtOther          []string `json:"roles_at_other,omitempty"`
+	TitleID               *int     `json:"title_id,omitempty"`
+}
+
+func (s CorporationTitle) String() string {
+	return Stringify(s)
+}
+
+// CorporationTitlesResponse holds the titles of a corporation.
+type CorporationTitlesResponse []*CorporationTitle
+
+// GetTitles returns the titles of a corporation.
+func (e *CorporationsEndpoint) GetTitles(ctx context.Context, cid int) (CorporationTitlesResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_titles", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var corporationTitlesResponse CorporationTitlesResponse
+	resp, err := e.api.Do(ctx, req, &corporationTitlesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return corporationTitlesResponse, resp, nil
+}
+
+// MemberTitles holds the titles held by a corporation member.
+type MemberTitles struct {
+	CharacterID *int  `json:"character_id,omitempty"`
+	Titles      []int `json:"titles,omitempty"`
+}
+
+func (s MemberTitles) String() string {
+	return Stringify(s)
+}
+
+// MemberTitlesResponse holds the titles of the members of a corporation.
+type MemberTitlesResponse []*MemberTitles
+
+// GetMemberTitles returns the titles held by the members of a corporation.
+func (e *CorporationsEndpoint) GetMemberTitles(ctx context.Context, cid int) (MemberTitlesResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_members_titles", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var memberTitlesResponse MemberTitlesResponse
+	resp, err := e.api.Do(ctx, req, &memberTitlesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return memberTitlesResponse, resp, nil
+}
+
+// Division holds the name of a hangar or wallet division.
+type Division struct {
+	Division *int    `json:"division,omitempty"`
+	Name     *string `json:"name,omitempty"`
+}
+
+// CorporationDivisions holds the hangar and wallet divisions of a
+// corporation.
+type CorporationDivisions struct {
+	Hangar []*Division `json:"hangar,omitempty"`
+	Wallet []*Division `json:"wallet,omitempty"`
+}
+
+func (s CorporationDivisions) String() string {
+	return Stringify(s)
+}
+
+// GetDivisions returns the hangar and wallet divisions of a corporation.
+func (e *CorporationsEndpoint) GetDivisions(ctx context.Context, cid int) (*CorporationDivisions, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_divisions", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	corporationDivisions := new(CorporationDivisions)
+	resp, err := e.api.Do(ctx, req, corporationDivisions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return corporationDivisions, resp, nil
+}
+
+// Shareholder holds details of a corporation shareholder.
+type Shareholder struct {
+	ShareCount      *int64  `json:"share_count,omitempty"`
+	ShareholderID   *int    `json:"shareholder_id,omitempty"`
+	ShareholderType *string `json:"shareholder_type,omitempty"`
+}
+
+func (s Shareholder) String() string {
+	return Stringify(s)
+}
+
+// ShareholdersResponse holds a list of shareholders.
+type ShareholdersResponse []*Shareholder
+
+// GetShareholders returns a page of the shareholders of a corporation.
+func (e *CorporationsEndpoint) GetShareholders(ctx context.Context, cid int, opt *ListOptions) (ShareholdersResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_shareholders", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var shareholdersResponse ShareholdersResponse
+	resp, err := e.api.Do(ctx, req, &shareholdersResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return shareholdersResponse, resp, nil
+}
+
+// StructureState is the state of an Upwell structure.
+type StructureState string
+
+// Upwell structure states.
+const (
+	StructureAnchorVulnerable    StructureState = "anchor_vulnerable"
+	StructureAnchoring           StructureState = "anchoring"
+	StructureArmorReinforce      StructureState = "armor_reinforce"
+	StructureArmorVulnerable     StructureState = "armor_vulnerable"
+	StructureDeployVulnerable    StructureState = "deploy_vulnerable"
+	StructureFittingInvulnerable StructureState = "fitting_invulnerable"
+	StructureHullReinforce       StructureState = "hull_reinforce"
+	StructureHullVulnerable      StructureState = "hull_vulnerable"
+	StructureOnlineDeprecated    StructureState = "online_deprecated"
+	StructureOnliningVulnerable  StructureState = "onlining_vulnerable"
+	StructureShieldVulnerable    StructureState = "shield_vulnerable"
+	StructureUnanchored          StructureState = "unanchored"
+	StructureUnknown             StructureState = "unknown"
+)
+
+// StructureServiceState is the state of a service module of an Upwell
+// structure.
+type StructureServiceState string
+
+// Upwell structure service states.
+const (
+	StructureServiceOnline  StructureServiceState = "online"
+	StructureServiceOffline StructureServiceState = "offline"
+	StructureServiceCleanup StructureServiceState = "cleanup"
+)
+
+// StructureService holds the state of a structure service module.
+type StructureService struct {
+	Name  *string                `json:"name,omitempty"`
+	State *StructureServiceState `json:"state,omitempty"`
+}
+
+// CorporationStructure holds details of a structure owned by a corporation.
+type CorporationStructure struct {
+	CorporationID      *int                `json:"corporation_id,omitempty"`
+	FuelExpires        *Timestamp          `json:"fuel_expires,omitempty"`
+	Name               *string             `json:"name,omitempty"`
+	NextReinforceApply *Timestamp          `json:"next_reinforce_apply,omitempty"`
+	NextReinforceHour  *int                `json:"next_reinforce_hour,omitempty"`
+	ProfileID          *int                `json:"profile_id,omitempty"`
+	ReinforceHour      *int                `json:"reinforce_hour,omitempty"`
+	Services           []*StructureService `json:"services,omitempty"`
+	State              *StructureState     `json:"state,omitempty"`
+	StateTimerEnd      *Timestamp          `json:"state_timer_end,omitempty"`
+	StateTimerStart    *Timestamp          `json:"state_timer_start,omitempty"`
+	StructureID        *int64              `json:"structure_id,omitempty"`
+	SystemID           *int                `json:"system_id,omitempty"`
+	TypeID             *int                `json:"type_id,omitempty"`
+	UnanchorsAt        *Timestamp          `json:"unanchors_at,omitempty"`
+}
+
+func (s CorporationStructure) String() string {
+	return Stringify(s)
+}
+
+// CorporationStructuresResponse holds a list of corporation structures.
+type CorporationStructuresResponse []*CorporationStructure
+
+// CorporationStructuresOptions specifies the optional parameters to the
+// CorporationsEndpoint.GetStructures method.
+type CorporationStructuresOptions struct {
+	ListOptions
+	I18NOptions
+}
+
+// GetStructures returns a page of the structures owned by a corporation.
+func (e *CorporationsEndpoint) GetStructures(ctx context.Context, cid int, opt *CorporationStructuresOptions) (CorporationStructuresResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_structures", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var corporationStructuresResponse CorporationStructuresResponse
+	resp, err := e.api.Do(ctx, req, &corporationStructuresResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return corporationStructuresResponse, resp, nil
+}
+
+// Starbase holds details of a starbase (POS) owned by a corporation.
+type Starbase struct {
+	MoonID          *int       `json:"moon_id,omitempty"`
+	OnlinedSince    *Timestamp `json:"onlined_since,omitempty"`
+	ReinforcedUntil *Timestamp `json:"reinforced_until,omitempty"`
+	StarbaseID      *int64     `json:"starbase_id,omitempty"`
+	State           *string    `json:"state,omitempty"`
+	SystemID        *int       `json:"system_id,omitempty"`
+	TypeID          *int       `json:"type_id,omitempty"`
+	UnanchorAt      *Timestamp `json:"unanchor_at,omitempty"`
+}
+
+func (s Starbase) String() string {
+	return Stringify(s)
+}
+
+// StarbasesResponse holds a list of starbases.
+type StarbasesResponse []*Starbase
+
+// GetStarbases returns a page of the starbases owned by a corporation.
+func (e *CorporationsEndpoint) GetStarbases(ctx context.Context, cid int, opt *ListOptions) (StarbasesResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_starbases", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var starbasesResponse StarbasesResponse
+	resp, err := e.api.Do(ctx, req, &starbasesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return starbasesResponse, resp, nil
+}
+
+// StarbaseFuel holds the quantity of a fuel type in a starbase.
+type StarbaseFuel struct {
+	Quantity *int `json:"quantity,omitempty"`
+	TypeID   *int `json:"type_id,omitempty"`
+}
+
+// StarbaseDetail holds the configuration and fuel status of a starbase.
+type StarbaseDetail struct {
+	AllowAllianceMembers                *bool           `json:"allow_alliance_members,omitempty"`
+	AllowCorporationMembers             *bool           `json:"allow_corporation_members,omitempty"`
+	Anchor                              *string         `json:"anchor,omitempty"`
+	AttackIfAtWar                       *bool           `json:"attack_if_at_war,omitempty"`
+	AttackIfOtherSecurityStatusDropping *bool           `json:"attack_if_other_security_status_dropping,omitempty"`
+	AttackSecurityStatusThreshold       *float64        `json:"attack_security_status_threshold,omitempty"`
+	AttackStandingThreshold             *float64        `json:"attack_standing_threshold,omitempty"`
+	FuelBayTake                         *string         `json:"fuel_bay_take,omitempty"`
+	FuelBayView                         *string         `json:"fuel_bay_view,omitempty"`
+	Fuels                               []*StarbaseFuel `json:"fuels,omitempty"`
+	Offline                             *string         `json:"offline,omitempty"`
+	Online                              *string         `json:"online,omitempty"`
+	Unanchor                            *string         `json:"unanchor,omitempty"`
+	UseAllianceStandings                *bool           `json:"use_alliance_standings,omitempty"`
+}
+
+func (s StarbaseDetail) String() string {
+	return Stringify(s)
+}
+
+// GetStarbase returns the configuration and fuel status of a starbase
+// anchored in the given solar system.
+func (e *CorporationsEndpoint) GetStarbase(ctx context.Context, cid int, sid int64, systemID int) (*StarbaseDetail, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_starbases_starbase_id", cid, sid)
+	u, err := addOptions(u, struct {
+		SystemID int `url:"system_id"`
+	}{systemID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	starbaseDetail := new(StarbaseDetail)
+	resp, err := e.api.Do(ctx, req, starbaseDetail)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return starbaseDetail, resp, nil
+}
+
+// Facility holds details of an industry facility owned by a corporation.
+type Facility struct {
+	FacilityID *int64 `json:"facility_id,omitempty"`
+	SystemID   *int   `json:"system_id,omitempty"`
+	TypeID     *int   `json:"type_id,omitempty"`
+}
+
+func (s Facility) String() string {
+	return Stringify(s)
+}
+
+// FacilitiesResponse holds a list of industry facilities.
+type FacilitiesResponse []*Facility
+
+// GetFacilities returns the industry facilities owned by a corporation.
+func (e *CorporationsEndpoint) GetFacilities(ctx context.Context, cid int) (FacilitiesResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_facilities", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var facilitiesResponse FacilitiesResponse
+	resp, err := e.api.Do(ctx, req, &facilitiesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return facilitiesResponse, resp, nil
+}
+
+// CorporationMedal holds details of a medal created by a corporation.
+type CorporationMedal struct {
+	CreatedAt   *Timestamp `json:"created_at,omitempty"`
+	CreatorID   *int       `json:"creator_id,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	MedalID     *int       `json:"medal_id,omitempty"`
+	Title       *string    `json:"title,omitempty"`
+}
+
+func (s CorporationMedal) String() string {
+	return Stringify(s)
+}
+
+// CorporationMedalsResponse holds a list of corporation medals.
+type CorporationMedalsResponse []*CorporationMedal
+
+// GetMedals returns a page of the medals created by a corporation.
+func (e *CorporationsEndpoint) GetMedals(ctx context.Context, cid int, opt *ListOptions) (CorporationMedalsResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_medals", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var corporationMedalsResponse CorporationMedalsResponse
+	resp, err := e.api.Do(ctx, req, &corporationMedalsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return corporationMedalsResponse, resp, nil
+}
+
+// IssuedMedal holds details of a medal issued by a corporation.
+type IssuedMedal struct {
+	CharacterID *int       `json:"character_id,omitempty"`
+	IssuedAt    *Timestamp `json:"issued_at,omitempty"`
+	IssuerID    *int       `json:"issuer_id,omitempty"`
+	MedalID     *int       `json:"medal_id,omitempty"`
+	Reason      *string    `json:"reason,omitempty"`
+	Status      *string    `json:"status,omitempty"`
+}
+
+func (s IssuedMedal) String() string {
+	return Stringify(s)
+}
+
+// IssuedMedalsResponse holds a list of issued medals.
+type IssuedMedalsResponse []*IssuedMedal
+
+// GetIssuedMedals returns a page of the medals issued by a corporation.
+func (e *CorporationsEndpoint) GetIssuedMedals(ctx context.Context, cid int, opt *ListOptions) (IssuedMedalsResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_medals_issued", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var issuedMedalsResponse IssuedMedalsResponse
+	resp, err := e.api.Do(ctx, req, &issuedMedalsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return issuedMedalsResponse, resp, nil
+}
+
+// ContainerLog holds an entry of the audit log of a secure container.
+type ContainerLog struct {
+	Action           *string    `json:"action,omitempty"`
+	CharacterID      *int       `json:"character_id,omitempty"`
+	ContainerID      *int64     `json:"container_id,omitempty"`
+	ContainerTypeID  *int       `json:"container_type_id,omitempty"`
+	LocationFlag     *string    `json:"location_flag,omitempty"`
+	LocationID       *int64     `json:"location_id,omitempty"`
+	LoggedAt         *Timestamp `json:"logged_at,omitempty"`
+	NewConfigBitmask *int       `json:"new_config_bitmask,omitempty"`
+	OldConfigBitmask *int       `json:"old_config_bitmask,omitempty"`
+	PasswordType     *string    `json:"password_type,omitempty"`
+	Quantity         *int       `json:"quantity,omitempty"`
+	TypeID           *int       `json:"type_id,omitempty"`
+}
+
+func (s ContainerLog) String() string {
+	return Stringify(s)
+}
+
+// ContainerLogsResponse holds a list of container log entries.
+type ContainerLogsResponse []*ContainerLog
+
+// GetContainerLogs returns a page of the audit logs of secure containers
+// owned by a corporation.
+func (e *CorporationsEndpoint) GetContainerLogs(ctx context.Context, cid int, opt *ListOptions) (ContainerLogsResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_containers_logs", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var containerLogsResponse ContainerLogsResponse
+	resp, err := e.api.Do(ctx, req, &containerLogsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return containerLogsResponse, resp, nil
+}
+
+// ListNPCCorporations returns the IDs of all NPC corporations.
+func (e *CorporationsEndpoint) ListNPCCorporations(ctx context.Context) ([]int, *Response, error) {
+	u := e.api.route("get_corporations_npccorps")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
@@ -0,0 +1,327 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCorporationsEndpoint_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v4/corporations/42/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			{
+				"ceo_id": 180548812,
+				"creator_id": 180548812,
+				"member_count": 656,
+				"name": "C C P",
+				"shares": 1000000000000,
+				"tax_rate": 0.256,
+				"ticker": "-CCP-"
+			}
+		`)
+	})
+
+	info, _, err := client.Corporations.Get(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Corporations.Get returned error: %v", err)
+	}
+
+	want := &CorporationPublicInfo{
+		CEOID:       Int(180548812),
+		CreatorID:   Int(180548812),
+		MemberCount: Int(656),
+		Name:        String("C C P"),
+		Shares:      Int64(1000000000000),
+		TaxRate:     Float64(0.256),
+		Ticker:      String("-CCP-"),
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("Corporations.Get returned %+v, want %+v", info, want)
+	}
+}
+
+func TestCorporationsEndpoint_GetAllianceHistory(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/corporations/42/alliancehistory/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			[
+				{
+					"alliance_id": 99000006,
+					"is_deleted": true,
+					"record_id": 23,
+					"start_date": "2016-10-25T14:46:00Z"
+				}
+			]
+		`)
+	})
+
+	history, _, err := client.Corporations.GetAllianceHistory(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Corporations.GetAllianceHistory returned error: %v", err)
+	}
+
+	want := AllianceHistoryResponse{
+		{
+			AllianceID: Int(99000006),
+			IsDeleted:  Bool(true),
+			RecordID:   Int(23),
+			StartDate:  &Timestamp{time.Date(2016, 10, 25, 14, 46, 0, 0, time.UTC)},
+		},
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("Corporations.GetAllianceHistory returned %+v, want %+v", history, want)
+	}
+}
+
+func TestCorporationsEndpoint_GetMembers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/corporations/42/members/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[90000001, 90000002]`)
+	})
+
+	members, _, err := client.Corporations.GetMembers(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Corporations.GetMembers returned error: %v", err)
+	}
+
+	want := []int{90000001, 90000002}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("Corporations.GetMembers returned %+v, want %+v", members, want)
+	}
+}
+
+func TestCorporationsEndpoint_GetDivisions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/corporations/42/divisions/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			{
+				"hangar": [{"division": 1, "name": "Awesome Hangar 1"}],
+				"wallet": [{"division": 1, "name": "Rich Wallet 1"}]
+			}
+		`)
+	})
+
+	divisions, _, err := client.Corporations.GetDivisions(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Corporations.GetDivisions returned error: %v", err)
+	}
+
+	want := &CorporationDivisions{
+		Hangar: []*Division{{Division: Int(1), Name: String("Awesome Hangar 1")}},
+		Wallet: []*Division{{Division: Int(1), Name: String("Rich Wallet 1")}},
+	}
+	if !reflect.DeepEqual(divisions, want) {
+		t.Errorf("Corporations.GetDivisions returned %+v, want %+v", divisions, want)
+	}
+}
+
+func TestCorporationsEndpoint_GetStructures(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/corporations/42/structures/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "2", "language": "de"})
+		w.Header().Set("X-Pages", "2")
+		fmt.Fprint(w, `
+			[
+				{
+					"corporation_id": 42,
+					"fuel_expires": "2018-03-01T12:00:00Z",
+					"profile_id": 11,
+					"services": [
+						{"name": "Clone Bay", "state": "online"},
+						{"name": "Market", "state": "offline"}
+					],
+					"state": "shield_vulnerable",
+					"structure_id": 1021975535893,
+					"system_id": 30000142,
+					"type_id": 35832
+				}
+			]
+		`)
+	})
+
+	opt := &CorporationStructuresOptions{
+		ListOptions: ListOptions{Page: 2},
+		I18NOptions: I18NOptions{Language: "de"},
+	}
+	structures, resp, err := client.Corporations.GetStructures(context.Background(), 42, opt)
+	if err != nil {
+		t.Errorf("Corporations.GetStructures returned error: %v", err)
+	}
+
+	online, offline := StructureServiceOnline, StructureServiceOffline
+	state := StructureShieldVulnerable
+	want := CorporationStructuresResponse{
+		{
+			CorporationID: Int(42),
+			FuelExpires:   &Timestamp{time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)},
+			ProfileID:     Int(11),
+			Services: []*StructureService{
+				{Name: String("Clone Bay"), State: &online},
+				{Name: String("Market"), State: &offline},
+			},
+			State:       &state,
+			StructureID: Int64(1021975535893),
+			SystemID:    Int(30000142),
+			TypeID:      Int(35832),
+		},
+	}
+	if !reflect.DeepEqual(structures, want) {
+		t.Errorf("Corporations.GetStructures returned %+v, want %+v", structures, want)
+	}
+
+	if resp.Pages != 2 || resp.Page != 2 {
+		t.Errorf("unexpected pagination; got page %d of %d", resp.Page, resp.Pages)
+	}
+}
+
+func TestCorporationsEndpoint_GetStarbase(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/corporations/42/starbases/1000000012/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"system_id": "30000142"})
+		fmt.Fprint(w, `
+			{
+				"allow_alliance_members": true,
+				"anchor": "config_starbase_equipment_role",
+				"fuels": [{"quantity": 960, "type_id": 4051}],
+				"use_alliance_standings": false
+			}
+		`)
+	})
+
+	starbase, _, err := client.Corporations.GetStarbase(context.Background(), 42, 1000000012, 30000142)
+	if err != nil {
+		t.Errorf("Corporations.GetStarbase returned error: %v", err)
+	}
+
+	want := &StarbaseDetail{
+		AllowAllianceMembers: Bool(true),
+		Anchor:               String("config_starbase_equipment_role"),
+		Fuels:                []*StarbaseFuel{{Quantity: Int(960), TypeID: Int(4051)}},
+		UseAllianceStandings: Bool(false),
+	}
+	if !reflect.DeepEqual(starbase, want) {
+		t.Errorf("Corporations.GetStarbase returned %+v, want %+v", starbase, want)
+	}
+}
+
+func TestCorporationsEndpoint_ListNPCCorporations(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/corporations/npccorps/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[1000001, 1000002]`)
+	})
+
+	ids, _, err := client.Corporations.ListNPCCorporations(context.Background())
+	if err != nil {
+		t.Errorf("Corporations.ListNPCCorporations returned error: %v", err)
+	}
+
+	want := []int{1000001, 1000002}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("Corporations.ListNPCCorporations returned %+v, want %+v", ids, want)
+	}
+}
+
+func TestCorporationsEndpoint_lists(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	for _, path := range []string{
+		"/v1/corporations/42/membertracking/",
+		"/v1/corporations/42/roles/",
+		"/v1/corporations/42/titles/",
+		"/v1/corporations/42/members/titles/",
+		"/v1/corporations/42/facilities/",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `[{}]`)
+		})
+	}
+
+	for _, path := range []string{
+		"/v1/corporations/42/roles/history/",
+		"/v1/corporations/42/shareholders/",
+		"/v1/corporations/42/starbases/",
+		"/v1/corporations/42/medals/",
+		"/v1/corporations/42/medals/issued/",
+		"/v2/corporations/42/containers/logs/",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			testFormValues(t, r, values{"page": "3"})
+			fmt.Fprint(w, `[{}]`)
+		})
+	}
+
+	ctx := context.Background()
+	opt := &ListOptions{Page: 3}
+
+	if v, _, err := client.Corporations.GetMemberTracking(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetMemberTracking returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetRoles(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetRoles returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetTitles(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetTitles returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetMemberTitles(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetMemberTitles returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetFacilities(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetFacilities returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetRolesHistory(ctx, 42, opt); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetRolesHistory returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetShareholders(ctx, 42, opt); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetShareholders returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetStarbases(ctx, 42, opt); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetStarbases returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetMedals(ctx, 42, opt); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetMedals returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetIssuedMedals(ctx, 42, opt); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetIssuedMedals returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Corporations.GetContainerLogs(ctx, 42, opt); err != nil || len(v) != 1 {
+		t.Errorf("Corporations.GetContainerLogs returned %v, %v", v, err)
+	}
+}
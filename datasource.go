@@ -0,0 +1,39 @@
+package esi
+
+import (
+	"context"
+	"net/url"
+)
+
+// ESI datasources.
+const (
+	// Tranquility is the main EVE Online server cluster.
+	Tranquility = "tranquility"
+
+	// Singularity is the EVE Online public test server cluster.
+	Singularity = "singularity"
+)
+
+type datasourceKey struct{}
+
+// WithDatasource returns a copy of ctx that makes requests carried out with it
+// target datasource, overriding the Datasource configured on the Client.
+func WithDatasource(ctx context.Context, datasource string) context.Context {
+	return context.WithValue(ctx, datasourceKey{}, datasource)
+}
+
+func datasourceFromContext(ctx context.Context) (string, bool) {
+	datasource, ok := ctx.Value(datasourceKey{}).(string)
+	return datasource, ok && datasource != ""
+}
+
+// withDatasource returns a copy of u with the datasource query parameter set.
+func withDatasource(u *url.URL, datasource string) *url.URL {
+	v := *u
+
+	q := v.Query()
+	q.Set("datasource", datasource)
+	v.RawQuery = q.Encode()
+
+	return &v
+}
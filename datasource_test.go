@@ -0,0 +1,54 @@
+package esi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestNewRequest_datasource(t *testing.T) {
+	c := NewClient(nil)
+	c.Datasource = Singularity
+
+	req, _ := c.NewRequest("GET", "v1/fleets/42/", nil)
+	if got, want := req.URL.String(), DefaultBaseURL+"v1/fleets/42/?datasource=singularity"; got != want {
+		t.Errorf("NewRequest URL is %v, want %v", got, want)
+	}
+
+	// an explicit datasource parameter is left alone
+	req, _ = c.NewRequest("GET", "v1/fleets/42/?datasource=tranquility", nil)
+	if got, want := req.URL.Query().Get("datasource"), Tranquility; got != want {
+		t.Errorf("datasource parameter is %v, want %v", got, want)
+	}
+}
+
+func TestDo_datasourceFromContext(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Datasource = Tranquility
+
+	mux.HandleFunc("/v1/fleets/42/", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"datasource": "singularity"})
+	})
+
+	ctx := WithDatasource(context.Background(), Singularity)
+	if _, _, err := client.Fleets.Get(ctx, 42); err != nil {
+		t.Errorf("Fleets.Get returned error: %v", err)
+	}
+}
+
+func TestDo_datasourceFromClient(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Datasource = Singularity
+
+	mux.HandleFunc("/v1/fleets/42/members/", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"datasource": "singularity", "language": "en-us"})
+	})
+
+	if _, _, err := client.Fleets.GetMembers(context.Background(), 42, &I18NOptions{Language: "en-us"}); err != nil {
+		t.Errorf("Fleets.GetMembers returned error: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package esi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors for classifying ESI failures with errors.Is. An Error
+// matches the sentinel corresponding to its status code.
+var (
+	// ErrBadRequest matches errors caused by invalid requests (HTTP 400).
+	ErrBadRequest = errors.New("esi: bad request")
+
+	// ErrUnauthorized matches errors caused by a missing or invalid access
+	// token (HTTP 401 or an EVE SSO failure).
+	ErrUnauthorized = errors.New("esi: unauthorized")
+
+	// ErrForbidden matches errors caused by missing scopes or in-game roles
+	// (HTTP 403).
+	ErrForbidden = errors.New("esi: forbidden")
+
+	// ErrNotFound matches errors for resources that do not exist (HTTP 404).
+	ErrNotFound = errors.New("esi: not found")
+
+	// ErrErrorLimited matches errors returned when the error limit has been
+	// exceeded (HTTP 420).
+	ErrErrorLimited = errors.New("esi: error limited")
+
+	// ErrTimeout matches errors caused by timeouts in ESI or its backend
+	// (HTTP 504 or any error carrying a timeout).
+	ErrTimeout = errors.New("esi: timeout")
+
+	// ErrServerError matches server side errors (HTTP 5xx).
+	ErrServerError = errors.New("esi: server error")
+)
+
+// Is reports whether e matches target, which should be one of the sentinel
+// errors of this package.
+func (e Error) Is(target error) bool {
+	code := e.HTTPStatusCode
+
+	switch target {
+	case ErrBadRequest:
+		return code == http.StatusBadRequest
+	case ErrUnauthorized:
+		return code == http.StatusUnauthorized || e.SSOStatus != 0
+	case ErrForbidden:
+		return code == http.StatusForbidden
+	case ErrNotFound:
+		return code == http.StatusNotFound
+	case ErrErrorLimited:
+		return code == StatusErrorLimited
+	case ErrTimeout:
+		return code == http.StatusGatewayTimeout || e.Timeout > 0
+	case ErrServerError:
+		return code >= 500
+	}
+
+	return false
+}
+
+// A DecodeError is returned when the body of a successful response cannot be
+// decoded.
+type DecodeError struct {
+	Method    string
+	Route     string
+	RequestID string
+
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("esi: decoding response (%s %s; request id %q): %v", e.Method, e.Route, e.RequestID, e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func wrapDecodeError(req *http.Request, resp *Response, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &DecodeError{
+		Method:    req.Method,
+		Route:     req.URL.Path,
+		RequestID: resp.RequestID,
+		Err:       err,
+	}
+}
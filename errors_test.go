@@ -0,0 +1,135 @@
+package esi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestError_Is(t *testing.T) {
+	var tests = []struct {
+		err  *Error
+		want []error
+	}{
+		{&Error{HTTPStatusCode: 400}, []error{ErrBadRequest}},
+		{&Error{HTTPStatusCode: 401}, []error{ErrUnauthorized}},
+		{&Error{HTTPStatusCode: 403}, []error{ErrForbidden}},
+		{&Error{HTTPStatusCode: 403, SSOStatus: 400}, []error{ErrForbidden, ErrUnauthorized}},
+		{&Error{HTTPStatusCode: 404}, []error{ErrNotFound}},
+		{&Error{HTTPStatusCode: 420}, []error{ErrErrorLimited}},
+		{&Error{HTTPStatusCode: 500}, []error{ErrServerError}},
+		{&Error{HTTPStatusCode: 500, Timeout: 10}, []error{ErrServerError, ErrTimeout}},
+		{&Error{HTTPStatusCode: 504}, []error{ErrServerError, ErrTimeout}},
+	}
+
+	sentinels := []error{
+		ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound,
+		ErrErrorLimited, ErrTimeout, ErrServerError,
+	}
+
+	for i, tt := range tests {
+		for _, sentinel := range sentinels {
+			var want bool
+			for _, w := range tt.want {
+				if w == sentinel {
+					want = true
+				}
+			}
+
+			if got := errors.Is(tt.err, sentinel); got != want {
+				t.Errorf("%d. errors.Is(%d, %v) => %v, want %v", i, tt.err.HTTPStatusCode, sentinel, got, want)
+			}
+		}
+	}
+}
+
+func TestError_IsValue(t *testing.T) {
+	err := fmt.Errorf("fetching fleet: %w", Error{HTTPStatusCode: 404})
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) => false, want true", err)
+	}
+
+	if errors.Is(err, ErrForbidden) {
+		t.Errorf("errors.Is(%v, ErrForbidden) => true, want false", err)
+	}
+}
+
+func TestDo_errorBody(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"token is expired","sso_status":400,"details":{"a":1}}`, 403)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	_, err := client.Do(context.Background(), req, nil)
+
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("expected *Error; got %T", err)
+	}
+
+	if e.SSOStatus != 400 {
+		t.Errorf("unexpected SSOStatus; got %d, want 400", e.SSOStatus)
+	}
+
+	if string(e.Details) != `{"a":1}` {
+		t.Errorf("unexpected Details; got %s", e.Details)
+	}
+
+	if !errors.Is(err, ErrForbidden) || !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected error to match ErrForbidden and ErrUnauthorized")
+	}
+}
+
+func TestDo_errorLimitedIs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"error limited"}`, StatusErrorLimited)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	_, err := client.Do(context.Background(), req, nil)
+
+	if !errors.Is(err, ErrErrorLimited) {
+		t.Fatalf("expected error to match ErrErrorLimited; got %v", err)
+	}
+
+	var e *Error
+	if !errors.As(err, &e) || e.HTTPStatusCode != StatusErrorLimited {
+		t.Fatalf("expected to unwrap to *Error; got %v", err)
+	}
+}
+
+func TestDo_decodeError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/fleets/42/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-ESI-Request-ID", "4b1d2a9e")
+		fmt.Fprint(w, `{"is_free_move": "yes"}`)
+	})
+
+	_, _, err := client.Fleets.Get(context.Background(), 42)
+
+	var e *DecodeError
+	if !errors.As(err, &e) {
+		t.Fatalf("expected *DecodeError; got %T", err)
+	}
+
+	if e.Method != "GET" || e.Route != baseURLPath+"/v1/fleets/42/" || e.RequestID != "4b1d2a9e" {
+		t.Errorf("unexpected DecodeError; got %+v", e)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Errorf("expected to unwrap to *json.UnmarshalTypeError; got %v", e.Err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,6 +36,7 @@ const (
 
 	headerErrorRateRemaining = "X-ESI-Error-Limit-Remain"
 	headerErrorRateReset     = "X-ESI-Error-Limit-Reset"
+	headerRequestID          = "X-ESI-Request-ID"
 )
 
 // A Client handles communication with the EVE Online Swagger Interface (ESI)
@@ -48,12 +50,56 @@ type Client struct {
 	// User agent used when communicating with ESI. You should set this.
 	UserAgent string
 
+	// Datasource is the server cluster to query; either Tranquility or
+	// Singularity. If empty, ESI defaults to Tranquility. Use WithDatasource
+	// to override the datasource for a single call.
+	Datasource string
+
+	// Version selects the version of every route. If empty, each route uses
+	// the version this library was written against. Otherwise it is one of
+	// VersionLatest, VersionLegacy or VersionDev, or a specific version such
+	// as "v2".
+	Version string
+
+	// Routes overrides the version of individual routes. It is keyed by ESI
+	// operation ID, such as "get_fleets_fleet_id", and takes precedence over
+	// Version.
+	Routes map[string]string
+
+	// OnDeprecation, if non-nil, is called for every deprecation warning
+	// received from ESI. Deprecation warnings are then no longer logged.
+	OnDeprecation func(resp *Response, w Warning)
+
 	// Logging holds optional loggers. If any are nil, logging is done via the
 	// log package's standard logger.
 	Logging struct {
 		Info, Error, Debug *log.Logger
 	}
 
+	// ETags holds entity tags and bodies of previous GET responses. If non-nil,
+	// conditional requests are made for URLs with a stored entry. Use
+	// NewMemoryETagStore for a simple in-memory store.
+	ETags ETagStore
+
+	// MaxConcurrentPages is the maximum number of pages a PageIterator will
+	// fetch concurrently.
+	MaxConcurrentPages int
+
+	// ErrorLimitThreshold is the number of remaining errors in the current
+	// error limit window below which requests are paused until the window
+	// resets. A value of zero disables throttling.
+	ErrorLimitThreshold int
+
+	// Retry configures retrying of failed requests. If nil, requests are not
+	// retried.
+	Retry *RetryPolicy
+
+	// Scopes holds the SSO scopes granted to the access token used by the
+	// client. If non-nil, requests for routes requiring other scopes fail
+	// with a *MissingScopeError without being sent. Use WithScopes to declare
+	// the scopes for a single call.
+	Scopes []string
+
 	mu struct {
 		sync.Mutex
 		Rate
@@ -62,7 +108,17 @@ type Client struct {
 	common endpoint // reuse a single struct for all endpoints
 
 	// Endpoints for talking to different parts of ESI.
-	Fleets *FleetsEndpoint
+	Alliances    *AlliancesEndpoint
+	Assets       *AssetsEndpoint
+	Characters   *CharactersEndpoint
+	Corporations *CorporationsEndpoint
+	Fleets       *FleetsEndpoint
+	Market       *MarketEndpoint
+	Universe     *UniverseEndpoint
+	Wallet       *WalletEndpoint
+
+	// Names resolves IDs to names in bulk, caching the results.
+	Names *NameResolver
 }
 
 // NewClient returns a new ESI API client. If a nil httpClient is provided,
@@ -83,12 +139,24 @@ func NewClient(httpClient *http.Client) *Client {
 		client:    httpClient,
 		BaseURL:   baseURL,
 		UserAgent: DefaultUserAgent,
+
+		MaxConcurrentPages:  DefaultMaxConcurrentPages,
+		ErrorLimitThreshold: DefaultErrorLimitThreshold,
 	}
 
 	api.common.api = api
 
 	// endpoints
+	api.Alliances = (*AlliancesEndpoint)(&api.common)
+	api.Assets = (*AssetsEndpoint)(&api.common)
+	api.Characters = (*CharactersEndpoint)(&api.common)
+	api.Corporations = (*CorporationsEndpoint)(&api.common)
 	api.Fleets = (*FleetsEndpoint)(&api.common)
+	api.Market = (*MarketEndpoint)(&api.common)
+	api.Universe = (*UniverseEndpoint)(&api.common)
+	api.Wallet = (*WalletEndpoint)(&api.common)
+
+	api.Names = NewNameResolver(api)
 
 	return api
 }
@@ -112,6 +180,10 @@ func (api *Client) NewRequest(method, url string, body interface{}) (*http.Reque
 		}
 	}
 
+	if api.Datasource != "" && u.Query().Get("datasource") == "" {
+		u = withDatasource(u, api.Datasource)
+	}
+
 	req, err := http.NewRequest(method, u.String(), buf)
 	if err != nil {
 		return nil, err
@@ -132,21 +204,162 @@ func (api *Client) NewRequest(method, url string, body interface{}) (*http.Reque
 
 // Response is an EVE Online ESI API response. This wraps the standard
 // http.Response returned from ESI and provides convenient access to deprecation
-// warnings and rate limit information.
+// warnings, rate limit and caching information.
 type Response struct {
 	*http.Response
+
+	// NotModified is true if ESI responded with 304 Not Modified and the
+	// result was decoded from the body stored in the client's ETagStore.
+	NotModified bool
+
+	// Caching information as reported by ESI. Zero values indicate that the
+	// corresponding header was absent or could not be parsed.
+	Date         time.Time
+	Expires      time.Time
+	LastModified time.Time
+	ETag         string
+	CacheControl CacheControl
+
+	// RequestID is the ESI request ID. Include it when reporting issues to
+	// CCP.
+	RequestID string
+
+	// Version is the route version that served the request, such as "v1" or
+	// "latest".
+	Version string
+
+	// Warnings holds any warnings returned by ESI, such as notices about
+	// deprecated routes.
+	Warnings []Warning
+
+	// Pagination information for paginated routes. Pages is the total number
+	// of pages as reported by the X-Pages header and zero for routes that are
+	// not paginated. Page is the page requested.
+	Pages int
+	Page  int
 }
 
 func makeResponse(r *http.Response) *Response {
-	return &Response{Response: r}
+	response := &Response{Response: r}
+	response.parseCacheHeaders()
+	response.parsePages()
+	response.Warnings = parseWarnings(r)
+
+	return response
+}
+
+func (r *Response) parseCacheHeaders() {
+	r.Date = parseHTTPTime(r.Header.Get("Date"))
+	r.Expires = parseHTTPTime(r.Header.Get("Expires"))
+	r.LastModified = parseHTTPTime(r.Header.Get("Last-Modified"))
+	r.ETag = r.Header.Get("ETag")
+	r.CacheControl = parseCacheControl(r.Header.Get("Cache-Control"))
+	r.RequestID = r.Header.Get(headerRequestID)
+}
+
+// NextRefresh returns the time at which the response becomes stale and ESI
+// may return new data. The Expires header is used if present, otherwise the
+// max-age directive of the Cache-Control header is used. The zero time is
+// returned if the response carries no caching information.
+func (r *Response) NextRefresh() time.Time {
+	if !r.Expires.IsZero() {
+		return r.Expires
+	}
+
+	if r.CacheControl.MaxAge > 0 {
+		date := r.Date
+		if date.IsZero() {
+			date = now()
+		}
+
+		return date.Add(r.CacheControl.MaxAge)
+	}
+
+	return time.Time{}
+}
+
+// FreshFor returns the duration for which the response remains fresh. It
+// returns zero if the response is already stale or carries no caching
+// information.
+func (r *Response) FreshFor() time.Duration {
+	next := r.NextRefresh()
+	if next.IsZero() {
+		return 0
+	}
+
+	if d := next.Sub(now()); d > 0 {
+		return d
+	}
+
+	return 0
+}
+
+// CacheControl holds the directives of a Cache-Control header.
+type CacheControl struct {
+	Public  bool
+	Private bool
+	NoCache bool
+	NoStore bool
+
+	// MaxAge is the value of the max-age directive or zero if absent.
+	MaxAge time.Duration
+}
+
+func parseCacheControl(s string) CacheControl {
+	var cc CacheControl
+
+	for _, directive := range strings.Split(s, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+
+		switch {
+		case directive == "public":
+			cc.Public = true
+		case directive == "private":
+			cc.Private = true
+		case directive == "no-cache":
+			cc.NoCache = true
+		case directive == "no-store":
+			cc.NoStore = true
+		case strings.HasPrefix(directive, "max-age="):
+			if v, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil && v > 0 {
+				cc.MaxAge = time.Duration(v) * time.Second
+			}
+		}
+	}
+
+	return cc
 }
 
-// Error represents an ESI API error.
+func parseHTTPTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+
+	t, err := http.ParseTime(s)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+// Error represents an ESI API error. Use errors.Is with the sentinel errors
+// such as ErrNotFound to determine the kind of failure.
 type Error struct {
 	Response       *http.Response
 	HTTPStatusCode int
 	Err            string `json:"error"`
 
+	// Timeout is set by ESI on errors caused by timeouts in the backend.
+	Timeout int `json:"timeout,omitempty"`
+
+	// SSOStatus is the status code returned by EVE SSO when validating the
+	// access token, if that failed.
+	SSOStatus int `json:"sso_status,omitempty"`
+
+	// Details holds any additional error details returned by ESI.
+	Details json.RawMessage `json:"details,omitempty"`
+
 	Rate
 }
 
@@ -163,7 +376,7 @@ func makeError(r *http.Response) *Error {
 	}
 
 	e.HTTPStatusCode = r.StatusCode
-	e.Rate = parseRate(r)
+	e.Rate, _ = parseRate(r)
 
 	return e
 }
@@ -178,25 +391,96 @@ func (r Rate) String() string {
 	return fmt.Sprintf("error rate limit: %d remaining calls; reset in %.fs", r.Remaining, r.Reset.Sub(now()).Seconds())
 }
 
-func parseRate(r *http.Response) Rate {
+// parseRate parses the error rate limit headers of r. The boolean result
+// reports whether the headers were present.
+func parseRate(r *http.Response) (Rate, bool) {
 	var rate Rate
-	if remaining := r.Header.Get(headerErrorRateRemaining); remaining != "" {
-		rate.Remaining, _ = strconv.Atoi(remaining)
+	remaining := r.Header.Get(headerErrorRateRemaining)
+	if remaining == "" {
+		return rate, false
 	}
 
+	rate.Remaining, _ = strconv.Atoi(remaining)
+
 	if reset := r.Header.Get(headerErrorRateReset); reset != "" {
 		if v, _ := strconv.Atoi(reset); v != 0 {
 			rate.Reset = now().Add(time.Duration(v) * time.Second)
 		}
 	}
 
-	return rate
+	return rate, true
 }
 
 // Do carries out a request and stores the result in v.
+//
+// If the client has an ETagStore, GET requests are made conditional on any
+// stored entity tag for the URL. On 304 Not Modified the stored body is
+// decoded into v and the NotModified field of the returned Response is set.
+//
+// If the client has a RetryPolicy, failed requests are retried as allowed by
+// the policy.
+//
+// If ctx carries a datasource (see WithDatasource) it overrides the datasource
+// of the request.
+//
+// If the granted scopes are known (see Client.Scopes and WithScopes) and the
+// route of the request requires a scope that has not been granted, a
+// *MissingScopeError is returned without making the request.
 func (api *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if err := api.checkScopes(ctx, req); err != nil {
+		return nil, err
+	}
+
 	req = req.WithContext(ctx)
 
+	if datasource, ok := datasourceFromContext(ctx); ok && req.URL != nil {
+		req.URL = withDatasource(req.URL, datasource)
+	}
+
+	for attempt := 1; ; attempt++ {
+		// each attempt gets its own copy, so changes made while sending one
+		// do not carry over to the next
+		response, err := api.do(ctx, req.Clone(ctx), v)
+
+		delay, ok := api.shouldRetry(ctx, req, response, err, attempt)
+		if !ok {
+			return response, err
+		}
+
+		logf(api.Logging.Info, "retrying request (%s %v) in %v after attempt %d: %v",
+			req.Method, req.URL.Path, delay, attempt, err,
+		)
+
+		if err := sleep(ctx, delay); err != nil {
+			return response, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return response, err
+			}
+
+			req.Body = body
+		}
+	}
+}
+
+func (api *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if err := api.waitErrorLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	// Requests that already carry an If-None-Match header are left alone, as
+	// a 304 Not Modified response to them cannot be served from the store.
+	var cached *ETagEntry
+	if api.ETags != nil && req.Method == "GET" && req.Header.Get("If-None-Match") == "" {
+		if entry, ok := api.ETags.Get(req.URL.String()); ok {
+			cached = entry
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+	}
+
 	// send request
 	resp, err := api.client.Do(req)
 	if err != nil {
@@ -207,36 +491,75 @@ func (api *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*R
 	defer resp.Body.Close()
 
 	response := makeResponse(resp)
+	response.Version = api.servedVersion(resp.Request.URL)
 
-	if err := api.check(resp); err != nil {
+	if api.OnDeprecation != nil {
+		for _, w := range response.Warnings {
+			if w.Deprecated() {
+				api.OnDeprecation(response, w)
+			}
+		}
+	}
+
+	if rate, ok := parseRate(resp); ok {
 		api.mu.Lock()
-		api.mu.Rate = err.(*Error).Rate
+		api.mu.Rate = rate
 		api.mu.Unlock()
+	}
 
-		return response, err
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		response.NotModified = true
+		return response, wrapDecodeError(req, response, decodeBody(bytes.NewReader(cached.Body), v))
 	}
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			io.Copy(w, resp.Body)
-		} else {
-			if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
-				if err == io.EOF {
-					err = nil
-				}
+	if err := api.check(resp); err != nil {
+		return response, err
+	}
 
+	var body io.Reader = resp.Body
+	if api.ETags != nil && req.Method == "GET" {
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
 				return response, err
 			}
+
+			api.ETags.Set(req.URL.String(), &ETagEntry{ETag: etag, Body: data})
+			body = bytes.NewReader(data)
 		}
 	}
 
-	return response, nil
+	return response, wrapDecodeError(req, response, decodeBody(body, v))
+}
+
+// decodeBody stores the contents of body in v. If v is an io.Writer the body
+// is copied verbatim, otherwise it is decoded as JSON.
+func decodeBody(body io.Reader, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+
+	if w, ok := v.(io.Writer); ok {
+		io.Copy(w, body)
+		return nil
+	}
+
+	if err := json.NewDecoder(body).Decode(v); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
 }
 
 func (api *Client) check(resp *http.Response) error {
 	if rc := resp.StatusCode; 200 <= rc && rc <= 299 {
-		// check for any waning headers and log them
-		if v := resp.Header.Get("warning"); v != "" {
+		// check for any warning headers and log them, except deprecations
+		// handled by OnDeprecation
+		for _, v := range resp.Header["Warning"] {
+			if w, ok := parseWarning(v); ok && w.Deprecated() && api.OnDeprecation != nil {
+				continue
+			}
+
 			logf(api.Logging.Error, "warning header received (%s %v): %s",
 				resp.Request.Method, resp.Request.URL.Path, v,
 			)
@@ -245,6 +568,10 @@ func (api *Client) check(resp *http.Response) error {
 		return nil
 	}
 
+	if resp.StatusCode == StatusErrorLimited {
+		return &ErrorLimitedError{Err: makeError(resp)}
+	}
+
 	return makeError(resp)
 }
 
@@ -34,6 +34,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -267,8 +268,13 @@ func TestDo_jsonSyntaxError(t *testing.T) {
 	body := new(foo)
 	_, err := client.Do(context.Background(), req, body)
 
-	if v, ok := err.(*json.SyntaxError); !ok {
-		t.Fatalf("expected json.SyntaxError; got %q", v)
+	var v *json.SyntaxError
+	if !errors.As(err, &v) {
+		t.Fatalf("expected json.SyntaxError; got %q", err)
+	}
+
+	if _, ok := err.(*DecodeError); !ok {
+		t.Fatalf("expected *DecodeError; got %T", err)
 	}
 }
 
@@ -441,3 +447,110 @@ func TestRate_string(t *testing.T) {
 		t.Fatalf("unexpected output from Rate.String(); got %q; want %q", got, expected)
 	}
 }
+
+func TestDo_cacheHeaders(t *testing.T) {
+	client, mux, _, teardown := setup()
+
+	staticNow := time.Date(2018, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	oldtime := now
+	now = func() time.Time {
+		return staticNow
+	}
+
+	defer func() {
+		now = oldtime
+		teardown()
+	}()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Expires", "Mon, 01 Jan 2018 18:05:00 GMT")
+		w.Header().Set("Last-Modified", "Mon, 01 Jan 2018 17:55:00 GMT")
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Header().Set("X-ESI-Request-ID", "4b1d2a9e")
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := time.Date(2018, 1, 1, 18, 5, 0, 0, time.UTC); !resp.Expires.Equal(want) {
+		t.Errorf("unexpected Expires; got %v, want %v", resp.Expires, want)
+	}
+
+	if want := time.Date(2018, 1, 1, 17, 55, 0, 0, time.UTC); !resp.LastModified.Equal(want) {
+		t.Errorf("unexpected LastModified; got %v, want %v", resp.LastModified, want)
+	}
+
+	if resp.ETag != `"abc"` {
+		t.Errorf("unexpected ETag; got %q, want %q", resp.ETag, `"abc"`)
+	}
+
+	if resp.RequestID != "4b1d2a9e" {
+		t.Errorf("unexpected RequestID; got %q, want %q", resp.RequestID, "4b1d2a9e")
+	}
+
+	want := CacheControl{Public: true, MaxAge: 300 * time.Second}
+	if resp.CacheControl != want {
+		t.Errorf("unexpected CacheControl; got %+v, want %+v", resp.CacheControl, want)
+	}
+
+	if got, want := resp.FreshFor(), 5*time.Minute; got != want {
+		t.Errorf("unexpected FreshFor(); got %v, want %v", got, want)
+	}
+
+	if got, want := resp.NextRefresh(), resp.Expires; !got.Equal(want) {
+		t.Errorf("unexpected NextRefresh(); got %v, want %v", got, want)
+	}
+}
+
+func TestResponse_FreshFor(t *testing.T) {
+	staticNow := time.Date(2018, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	oldtime := now
+	now = func() time.Time {
+		return staticNow
+	}
+
+	defer func() {
+		now = oldtime
+	}()
+
+	var tests = []struct {
+		resp *Response
+		want time.Duration
+	}{
+		{&Response{}, 0},
+		{&Response{Expires: staticNow.Add(-time.Minute)}, 0},
+		{&Response{Expires: staticNow.Add(time.Minute)}, time.Minute},
+		{&Response{CacheControl: CacheControl{MaxAge: time.Minute}}, time.Minute},
+		{&Response{Date: staticNow.Add(-30 * time.Second), CacheControl: CacheControl{MaxAge: time.Minute}}, 30 * time.Second},
+	}
+
+	for i, tt := range tests {
+		if got := tt.resp.FreshFor(); got != tt.want {
+			t.Errorf("%d. FreshFor() => %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+func TestParseCacheControl(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want CacheControl
+	}{
+		{"", CacheControl{}},
+		{"public, max-age=5", CacheControl{Public: true, MaxAge: 5 * time.Second}},
+		{"Private,No-Cache", CacheControl{Private: true, NoCache: true}},
+		{"no-store, max-age=invalid", CacheControl{NoStore: true}},
+	}
+
+	for i, tt := range tests {
+		if got := parseCacheControl(tt.in); got != tt.want {
+			t.Errorf("%d. parseCacheControl(%q) => %+v, want %+v", i, tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,713 @@
+package esitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fleet roles.
+const (
+	RoleFleetCommander = "fleet_commander"
+	RoleWingCommander  = "wing_commander"
+	RoleSquadCommander = "squad_commander"
+	RoleSquadMember    = "squad_member"
+)
+
+// Fleet limits as enforced by EVE.
+const (
+	DefaultMaxFleetMembers = 256
+	DefaultMaxWings        = 25
+	DefaultMaxSquads       = 25
+	DefaultMaxSquadMembers = 256
+)
+
+// Defaults for the location of invited members.
+const (
+	defaultShipTypeID    = 670      // Capsule
+	defaultSolarSystemID = 30000142 // Jita
+)
+
+// A FleetMember is a member of a simulated fleet. WingID and SquadID are -1
+// for positions outside of a wing or squad, as reported by ESI.
+type FleetMember struct {
+	CharacterID    int
+	Role           string
+	WingID         int
+	SquadID        int
+	JoinTime       time.Time
+	ShipTypeID     int
+	SolarSystemID  int
+	StationID      int
+	TakesFleetWarp bool
+}
+
+// A FleetSquad is a squad of a simulated fleet.
+type FleetSquad struct {
+	ID   int
+	Name string
+}
+
+// A FleetWing is a wing of a simulated fleet.
+type FleetWing struct {
+	ID     int
+	Name   string
+	Squads []FleetSquad
+}
+
+// FleetState is a snapshot of the state of a simulated fleet.
+type FleetState struct {
+	BossID         int
+	IsFreeMove     bool
+	IsRegistered   bool
+	IsVoiceEnabled bool
+	MOTD           string
+
+	// Wings holds the wings and their squads, in order of creation.
+	Wings []FleetWing
+
+	// Members holds the members, sorted by character ID.
+	Members []FleetMember
+}
+
+// A Fleet is a fleet simulated by a Server. The fleet routes of the esi
+// package change and report its state, rejecting invalid operations with the
+// errors ESI returns. Invitations are accepted immediately.
+//
+// The limits must not be changed once requests are made.
+type Fleet struct {
+	ID int
+
+	// Limits on the size of the fleet. NewFleet sets them to the defaults.
+	MaxMembers      int
+	MaxWings        int
+	MaxSquads       int
+	MaxSquadMembers int
+
+	mu      sync.Mutex
+	state   FleetState
+	members map[int]*FleetMember
+	nextID  int
+	now     func() time.Time
+}
+
+// fleetError is an error returned by the simulator.
+type fleetError struct {
+	status int
+	msg    string
+}
+
+func errorf(status int, msg string) *fleetError {
+	return &fleetError{status: status, msg: msg}
+}
+
+func (e *fleetError) Error() string {
+	return "esitest: " + e.msg
+}
+
+var (
+	errMemberNotFound = errorf(http.StatusNotFound, "Fleet member not found")
+	errWingNotFound   = errorf(http.StatusNotFound, "Wing not found")
+	errSquadNotFound  = errorf(http.StatusNotFound, "Squad not found")
+)
+
+// NewFleet adds a fleet with the given ID to the server. The boss is the fleet
+// commander. Like a fleet formed in game, it starts with a single wing holding
+// a single squad.
+//
+// The fleet routes are served for any route version, so clients selecting
+// versions with Client.Version or Client.Routes reach the simulator too.
+func (s *Server) NewFleet(fid, bossID int) *Fleet {
+	f := &Fleet{
+		ID:              fid,
+		MaxMembers:      DefaultMaxFleetMembers,
+		MaxWings:        DefaultMaxWings,
+		MaxSquads:       DefaultMaxSquads,
+		MaxSquadMembers: DefaultMaxSquadMembers,
+		members:         make(map[int]*FleetMember),
+		nextID:          fid*100 + 1,
+		now:             s.now,
+	}
+
+	f.state.BossID = bossID
+
+	wid := f.newID()
+	f.state.Wings = []FleetWing{{ID: wid, Name: "Wing 1", Squads: []FleetSquad{{ID: f.newID(), Name: "Squad 1"}}}}
+	f.members[bossID] = f.newMember(bossID, RoleFleetCommander, -1, -1)
+
+	s.mu.Lock()
+	if s.fleets == nil {
+		s.fleets = make(map[int]*Fleet)
+	}
+	s.fleets[fid] = f
+	s.mu.Unlock()
+
+	s.HandleFunc("GET", "/*/characters/*/fleet/", s.characterFleet)
+
+	prefix := "/*/fleets/" + strconv.Itoa(fid)
+	s.HandleFunc("GET", prefix+"/", f.get)
+	s.HandleFunc("PUT", prefix+"/", f.update)
+	s.HandleFunc("GET", prefix+"/members/", f.getMembers)
+	s.HandleFunc("POST", prefix+"/members/", f.invite)
+	s.HandleFunc("DELETE", prefix+"/members/*/", f.kick)
+	s.HandleFunc("PUT", prefix+"/members/*/", f.move)
+	s.HandleFunc("DELETE", prefix+"/squads/*/", f.deleteSquad)
+	s.HandleFunc("PUT", prefix+"/squads/*/", f.renameSquad)
+	s.HandleFunc("GET", prefix+"/wings/", f.getWings)
+	s.HandleFunc("POST", prefix+"/wings/", f.createWing)
+	s.HandleFunc("DELETE", prefix+"/wings/*/", f.deleteWing)
+	s.HandleFunc("PUT", prefix+"/wings/*/", f.renameWing)
+	s.HandleFunc("POST", prefix+"/wings/*/squads/", f.createSquad)
+
+	return f
+}
+
+// State returns a snapshot of the state of the fleet.
+func (f *Fleet) State() FleetState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state := f.state
+
+	state.Wings = make([]FleetWing, len(f.state.Wings))
+	for i, w := range f.state.Wings {
+		w.Squads = append([]FleetSquad(nil), w.Squads...)
+		state.Wings[i] = w
+	}
+
+	state.Members = make([]FleetMember, 0, len(f.members))
+	for _, m := range f.members {
+		state.Members = append(state.Members, *m)
+	}
+
+	sort.Slice(state.Members, func(i, j int) bool {
+		return state.Members[i].CharacterID < state.Members[j].CharacterID
+	})
+
+	return state
+}
+
+// Join adds a character to the fleet as if invited with the given role, wing
+// and squad. Zero wing and squad IDs are treated as absent.
+func (f *Fleet) Join(cid int, role string, wid, sid int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.join(cid, role, wid, sid); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (f *Fleet) newID() int {
+	id := f.nextID
+	f.nextID++
+
+	return id
+}
+
+func (f *Fleet) newMember(cid int, role string, wid, sid int) *FleetMember {
+	return &FleetMember{
+		CharacterID:    cid,
+		Role:           role,
+		WingID:         wid,
+		SquadID:        sid,
+		JoinTime:       f.now().UTC().Truncate(time.Second),
+		ShipTypeID:     defaultShipTypeID,
+		SolarSystemID:  defaultSolarSystemID,
+		TakesFleetWarp: true,
+	}
+}
+
+func (f *Fleet) wing(wid int) *FleetWing {
+	for i := range f.state.Wings {
+		if f.state.Wings[i].ID == wid {
+			return &f.state.Wings[i]
+		}
+	}
+
+	return nil
+}
+
+// squad returns the wing holding squad sid, and the index of the squad.
+func (f *Fleet) squad(sid int) (*FleetWing, int) {
+	for i := range f.state.Wings {
+		for j, sq := range f.state.Wings[i].Squads {
+			if sq.ID == sid {
+				return &f.state.Wings[i], j
+			}
+		}
+	}
+
+	return nil, -1
+}
+
+// occupant returns the member holding the given role and position, if any.
+func (f *Fleet) occupant(role string, wid, sid int) *FleetMember {
+	for _, m := range f.members {
+		if m.Role == role && m.WingID == wid && m.SquadID == sid {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// squadSize returns the number of members in squad sid, including its
+// commander.
+func (f *Fleet) squadSize(sid int) int {
+	n := 0
+	for _, m := range f.members {
+		if m.SquadID == sid {
+			n++
+		}
+	}
+
+	return n
+}
+
+// place validates the position of character cid with the given role, wing
+// and squad, and returns the wing and squad IDs it resolves to.
+func (f *Fleet) place(cid int, role string, wid, sid int) (int, int, *fleetError) {
+	switch role {
+	case RoleFleetCommander:
+		if wid != 0 || sid != 0 {
+			return 0, 0, errorf(http.StatusUnprocessableEntity, "A fleet commander cannot be placed in a wing or squad")
+		}
+
+		wid, sid = -1, -1
+	case RoleWingCommander:
+		if wid == 0 || sid != 0 {
+			return 0, 0, errorf(http.StatusUnprocessableEntity, "A wing commander must be placed in a wing and not in a squad")
+		}
+
+		if f.wing(wid) == nil {
+			return 0, 0, errWingNotFound
+		}
+
+		sid = -1
+	case RoleSquadCommander, RoleSquadMember:
+		if wid == 0 && sid == 0 && role == RoleSquadMember {
+			// any squad with room
+			for _, w := range f.state.Wings {
+				for _, sq := range w.Squads {
+					if f.squadSize(sq.ID) < f.MaxSquadMembers {
+						return w.ID, sq.ID, nil
+					}
+				}
+			}
+
+			return 0, 0, errorf(http.StatusUnprocessableEntity, "There is no squad with room in the fleet")
+		}
+
+		if wid == 0 || sid == 0 {
+			return 0, 0, errorf(http.StatusUnprocessableEntity, "Both a wing and a squad must be given")
+		}
+
+		w, _ := f.squad(sid)
+		if w == nil {
+			return 0, 0, errSquadNotFound
+		}
+
+		if w.ID != wid {
+			return 0, 0, errorf(http.StatusUnprocessableEntity, "The squad is not in the wing")
+		}
+
+		if m, ok := f.members[cid]; (!ok || m.SquadID != sid) && f.squadSize(sid) >= f.MaxSquadMembers {
+			return 0, 0, errorf(http.StatusUnprocessableEntity, "The squad is full")
+		}
+	default:
+		return 0, 0, errorf(http.StatusBadRequest, "Invalid role: "+role)
+	}
+
+	if role != RoleSquadMember {
+		if m := f.occupant(role, wid, sid); m != nil && m.CharacterID != cid {
+			return 0, 0, errorf(http.StatusUnprocessableEntity, "The position is already taken")
+		}
+	}
+
+	return wid, sid, nil
+}
+
+func (f *Fleet) join(cid int, role string, wid, sid int) *fleetError {
+	if _, ok := f.members[cid]; ok {
+		return errorf(http.StatusUnprocessableEntity, "The character is already in the fleet")
+	}
+
+	if len(f.members) >= f.MaxMembers {
+		return errorf(http.StatusUnprocessableEntity, "The fleet is full")
+	}
+
+	wid, sid, err := f.place(cid, role, wid, sid)
+	if err != nil {
+		return err
+	}
+
+	f.members[cid] = f.newMember(cid, role, wid, sid)
+
+	return nil
+}
+
+// handle decodes the request body into v, if non-nil, and calls fn with the
+// fleet locked and the IDs in the path.
+func (f *Fleet) handle(w http.ResponseWriter, r *http.Request, v interface{}, fn func(ids []int) (interface{}, *fleetError)) {
+	if v != nil {
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			writeError(w, errorf(http.StatusBadRequest, "Invalid body: "+err.Error()))
+			return
+		}
+	}
+
+	var ids []int
+	for _, seg := range strings.Split(r.URL.Path, "/") {
+		if id, err := strconv.Atoi(seg); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	f.mu.Lock()
+	res, err := fn(ids)
+	f.mu.Unlock()
+
+	switch {
+	case err != nil:
+		writeError(w, err)
+	case res == nil:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		status := http.StatusOK
+		if r.Method == "POST" {
+			status = http.StatusCreated
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+func writeError(w http.ResponseWriter, err *fleetError) {
+	body, _ := json.Marshal(map[string]string{"error": err.msg})
+	writeJSON(w, err.status, string(body))
+}
+
+// characterFleet serves the fleet of a character from all fleets of the
+// server.
+func (s *Server) characterFleet(w http.ResponseWriter, r *http.Request) {
+	cid, _ := strconv.Atoi(strings.Split(r.URL.Path, "/")[3])
+
+	s.mu.Lock()
+	fleets := make([]*Fleet, 0, len(s.fleets))
+	for _, f := range s.fleets {
+		fleets = append(fleets, f)
+	}
+	s.mu.Unlock()
+
+	for _, f := range fleets {
+		f.mu.Lock()
+		m, ok := f.members[cid]
+		if ok {
+			m := *m
+			f.mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"fleet_id": f.ID,
+				"role":     m.Role,
+				"squad_id": m.SquadID,
+				"wing_id":  m.WingID,
+			})
+
+			return
+		}
+		f.mu.Unlock()
+	}
+
+	writeError(w, errorf(http.StatusNotFound, "Character is not in a fleet"))
+}
+
+func (f *Fleet) get(w http.ResponseWriter, r *http.Request) {
+	f.handle(w, r, nil, func([]int) (interface{}, *fleetError) {
+		return map[string]interface{}{
+			"is_free_move":     f.state.IsFreeMove,
+			"is_registered":    f.state.IsRegistered,
+			"is_voice_enabled": f.state.IsVoiceEnabled,
+			"motd":             f.state.MOTD,
+		}, nil
+	})
+}
+
+func (f *Fleet) update(w http.ResponseWriter, r *http.Request) {
+	var v struct {
+		IsFreeMove *bool   `json:"is_free_move"`
+		MOTD       *string `json:"motd"`
+	}
+
+	f.handle(w, r, &v, func([]int) (interface{}, *fleetError) {
+		if v.IsFreeMove != nil {
+			f.state.IsFreeMove = *v.IsFreeMove
+		}
+
+		if v.MOTD != nil {
+			f.state.MOTD = *v.MOTD
+		}
+
+		return nil, nil
+	})
+}
+
+var roleNames = map[string]string{
+	RoleFleetCommander: "Fleet Commander",
+	RoleWingCommander:  "Wing Commander",
+	RoleSquadCommander: "Squad Commander",
+	RoleSquadMember:    "Squad Member",
+}
+
+func (f *Fleet) getMembers(w http.ResponseWriter, r *http.Request) {
+	f.handle(w, r, nil, func([]int) (interface{}, *fleetError) {
+		members := []map[string]interface{}{}
+		for _, m := range f.sortedMembers() {
+			name := roleNames[m.Role]
+			if m.CharacterID == f.state.BossID {
+				name += " (Boss)"
+			}
+
+			v := map[string]interface{}{
+				"character_id":     m.CharacterID,
+				"join_time":        m.JoinTime.Format(time.RFC3339),
+				"role":             m.Role,
+				"role_name":        name,
+				"ship_type_id":     m.ShipTypeID,
+				"solar_system_id":  m.SolarSystemID,
+				"squad_id":         m.SquadID,
+				"takes_fleet_warp": m.TakesFleetWarp,
+				"wing_id":          m.WingID,
+			}
+
+			if m.StationID != 0 {
+				v["station_id"] = m.StationID
+			}
+
+			members = append(members, v)
+		}
+
+		return members, nil
+	})
+}
+
+func (f *Fleet) sortedMembers() []*FleetMember {
+	members := make([]*FleetMember, 0, len(f.members))
+	for _, m := range f.members {
+		members = append(members, m)
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].CharacterID < members[j].CharacterID })
+
+	return members
+}
+
+type fleetPosition struct {
+	CharacterID int    `json:"character_id"`
+	Role        string `json:"role"`
+	SquadID     int    `json:"squad_id"`
+	WingID      int    `json:"wing_id"`
+}
+
+func (f *Fleet) invite(w http.ResponseWriter, r *http.Request) {
+	var v fleetPosition
+
+	f.handle(w, r, &v, func([]int) (interface{}, *fleetError) {
+		return nil, f.join(v.CharacterID, v.Role, v.WingID, v.SquadID)
+	})
+}
+
+func (f *Fleet) kick(w http.ResponseWriter, r *http.Request) {
+	f.handle(w, r, nil, func(ids []int) (interface{}, *fleetError) {
+		if _, ok := f.members[ids[1]]; !ok {
+			return nil, errMemberNotFound
+		}
+
+		delete(f.members, ids[1])
+
+		return nil, nil
+	})
+}
+
+func (f *Fleet) move(w http.ResponseWriter, r *http.Request) {
+	var v fleetPosition
+
+	f.handle(w, r, &v, func(ids []int) (interface{}, *fleetError) {
+		m, ok := f.members[ids[1]]
+		if !ok {
+			return nil, errMemberNotFound
+		}
+
+		wid, sid, err := f.place(m.CharacterID, v.Role, v.WingID, v.SquadID)
+		if err != nil {
+			return nil, err
+		}
+
+		m.Role, m.WingID, m.SquadID = v.Role, wid, sid
+
+		return nil, nil
+	})
+}
+
+// validName checks a wing or squad name, which ESI limits to 10 characters.
+func validName(name string) *fleetError {
+	if n := len([]rune(name)); n == 0 || n > 10 {
+		return errorf(http.StatusBadRequest, "Name must be between 1 and 10 characters long")
+	}
+
+	return nil
+}
+
+func (f *Fleet) deleteSquad(w http.ResponseWriter, r *http.Request) {
+	f.handle(w, r, nil, func(ids []int) (interface{}, *fleetError) {
+		wing, i := f.squad(ids[1])
+		if wing == nil {
+			return nil, errSquadNotFound
+		}
+
+		if f.squadSize(ids[1]) > 0 {
+			return nil, errorf(http.StatusUnprocessableEntity, "Only empty squads can be deleted")
+		}
+
+		wing.Squads = append(wing.Squads[:i], wing.Squads[i+1:]...)
+
+		return nil, nil
+	})
+}
+
+func (f *Fleet) renameSquad(w http.ResponseWriter, r *http.Request) {
+	var v struct {
+		Name string `json:"name"`
+	}
+
+	f.handle(w, r, &v, func(ids []int) (interface{}, *fleetError) {
+		wing, i := f.squad(ids[1])
+		if wing == nil {
+			return nil, errSquadNotFound
+		}
+
+		if err := validName(v.Name); err != nil {
+			return nil, err
+		}
+
+		wing.Squads[i].Name = v.Name
+
+		return nil, nil
+	})
+}
+
+func (f *Fleet) getWings(w http.ResponseWriter, r *http.Request) {
+	f.handle(w, r, nil, func([]int) (interface{}, *fleetError) {
+		type squad struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+
+		type wing struct {
+			ID     int     `json:"id"`
+			Name   string  `json:"name"`
+			Squads []squad `json:"squads"`
+		}
+
+		wings := make([]wing, 0, len(f.state.Wings))
+		for _, w := range f.state.Wings {
+			squads := make([]squad, 0, len(w.Squads))
+			for _, sq := range w.Squads {
+				squads = append(squads, squad{sq.ID, sq.Name})
+			}
+
+			wings = append(wings, wing{w.ID, w.Name, squads})
+		}
+
+		return wings, nil
+	})
+}
+
+func (f *Fleet) createWing(w http.ResponseWriter, r *http.Request) {
+	f.handle(w, r, nil, func([]int) (interface{}, *fleetError) {
+		if len(f.state.Wings) >= f.MaxWings {
+			return nil, errorf(http.StatusUnprocessableEntity, "The fleet has the maximum number of wings")
+		}
+
+		wid := f.newID()
+		f.state.Wings = append(f.state.Wings, FleetWing{
+			ID:   wid,
+			Name: "Wing " + strconv.Itoa(len(f.state.Wings)+1),
+		})
+
+		return map[string]int{"wing_id": wid}, nil
+	})
+}
+
+func (f *Fleet) deleteWing(w http.ResponseWriter, r *http.Request) {
+	f.handle(w, r, nil, func(ids []int) (interface{}, *fleetError) {
+		for i, wing := range f.state.Wings {
+			if wing.ID != ids[1] {
+				continue
+			}
+
+			for _, m := range f.members {
+				if m.WingID == wing.ID {
+					return nil, errorf(http.StatusUnprocessableEntity, "Only empty wings can be deleted")
+				}
+			}
+
+			f.state.Wings = append(f.state.Wings[:i], f.state.Wings[i+1:]...)
+
+			return nil, nil
+		}
+
+		return nil, errWingNotFound
+	})
+}
+
+func (f *Fleet) renameWing(w http.ResponseWriter, r *http.Request) {
+	var v struct {
+		Name string `json:"name"`
+	}
+
+	f.handle(w, r, &v, func(ids []int) (interface{}, *fleetError) {
+		wing := f.wing(ids[1])
+		if wing == nil {
+			return nil, errWingNotFound
+		}
+
+		if err := validName(v.Name); err != nil {
+			return nil, err
+		}
+
+		wing.Name = v.Name
+
+		return nil, nil
+	})
+}
+
+func (f *Fleet) createSquad(w http.ResponseWriter, r *http.Request) {
+	f.handle(w, r, nil, func(ids []int) (interface{}, *fleetError) {
+		wing := f.wing(ids[1])
+		if wing == nil {
+			return nil, errWingNotFound
+		}
+
+		if len(wing.Squads) >= f.MaxSquads {
+			return nil, errorf(http.StatusUnprocessableEntity, "The wing has the maximum number of squads")
+		}
+
+		sid := f.newID()
+		wing.Squads = append(wing.Squads, FleetSquad{
+			ID:   sid,
+			Name: "Squad " + strconv.Itoa(len(wing.Squads)+1),
+		})
+
+		return map[string]int{"squad_id": sid}, nil
+	})
+}
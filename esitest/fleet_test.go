@@ -0,0 +1,393 @@
+package esitest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"corpus.space/esi"
+)
+
+func newFleet() (*Server, *Fleet, *esi.Client) {
+	s := NewServer()
+	f := s.NewFleet(42, 1)
+
+	api := s.Client()
+	api.ErrorLimitThreshold = 0
+
+	return s, f, api
+}
+
+// statusOf returns the HTTP status code of an ESI error.
+func statusOf(err error) int {
+	var e *esi.Error
+	if errors.As(err, &e) {
+		return e.HTTPStatusCode
+	}
+
+	return 0
+}
+
+func TestFleet_initial(t *testing.T) {
+	s, f, api := newFleet()
+	defer s.Close()
+
+	ctx := context.Background()
+
+	wings, _, err := api.Fleets.GetWings(ctx, 42, nil)
+	if err != nil {
+		t.Fatalf("GetWings returned error: %v", err)
+	}
+
+	state := f.State()
+	want := esi.FleetWingsResponse{{
+		ID:     esi.Int(state.Wings[0].ID),
+		Name:   esi.String("Wing 1"),
+		Squads: []*esi.FleetSquad{{ID: esi.Int(state.Wings[0].Squads[0].ID), Name: esi.String("Squad 1")}},
+	}}
+	if !reflect.DeepEqual(wings, want) {
+		t.Errorf("GetWings returned %v, want %v", wings, want)
+	}
+
+	members, _, err := api.Fleets.GetMembers(ctx, 42, nil)
+	if err != nil {
+		t.Fatalf("GetMembers returned error: %v", err)
+	}
+
+	if len(members) != 1 {
+		t.Fatalf("GetMembers returned %d members, want 1", len(members))
+	}
+
+	m := members[0]
+	if *m.CharacterID != 1 || *m.Role != RoleFleetCommander || *m.RoleName != "Fleet Commander (Boss)" || *m.WingID != -1 || *m.SquadID != -1 {
+		t.Errorf("GetMembers returned %v", m)
+	}
+
+	if *m.ShipTypeID != defaultShipTypeID || m.JoinTime == nil {
+		t.Errorf("GetMembers returned %v", m)
+	}
+
+	cf, _, err := api.Fleets.GetCharacterFleet(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetCharacterFleet returned error: %v", err)
+	}
+
+	if *cf.FleetID != 42 || *cf.Role != RoleFleetCommander {
+		t.Errorf("GetCharacterFleet returned %v", cf)
+	}
+
+	if _, _, err := api.Fleets.GetCharacterFleet(ctx, 2); !errors.Is(err, esi.ErrNotFound) {
+		t.Errorf("GetCharacterFleet returned error %v, want ErrNotFound", err)
+	}
+}
+
+func TestFleet_Update(t *testing.T) {
+	s, f, api := newFleet()
+	defer s.Close()
+
+	ctx := context.Background()
+
+	if _, err := api.Fleets.Update(ctx, 42, &esi.FleetSettings{IsFreeMove: true, MOTD: "hello"}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+
+	fleet, _, err := api.Fleets.Get(ctx, 42)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if !*fleet.IsFreeMove || *fleet.MOTD != "hello" {
+		t.Errorf("Get returned %v", fleet)
+	}
+
+	// omitted settings are left alone
+	api.Fleets.Update(ctx, 42, &esi.FleetSettings{MOTD: "bye"})
+
+	if state := f.State(); !state.IsFreeMove || state.MOTD != "bye" {
+		t.Errorf("fleet state is %+v", state)
+	}
+}
+
+func TestFleet_structure(t *testing.T) {
+	s, f, api := newFleet()
+	defer s.Close()
+
+	ctx := context.Background()
+
+	wid, _, err := api.Fleets.CreateWing(ctx, 42)
+	if err != nil {
+		t.Fatalf("CreateWing returned error: %v", err)
+	}
+
+	sid, _, err := api.Fleets.CreateSquad(ctx, 42, wid)
+	if err != nil {
+		t.Fatalf("CreateSquad returned error: %v", err)
+	}
+
+	if _, err := api.Fleets.RenameWing(ctx, 42, wid, "Logi"); err != nil {
+		t.Errorf("RenameWing returned error: %v", err)
+	}
+
+	if _, err := api.Fleets.RenameSquad(ctx, 42, sid, "Alpha"); err != nil {
+		t.Errorf("RenameSquad returned error: %v", err)
+	}
+
+	state := f.State()
+	if len(state.Wings) != 2 {
+		t.Fatalf("fleet has %d wings, want 2", len(state.Wings))
+	}
+
+	want := FleetWing{ID: wid, Name: "Logi", Squads: []FleetSquad{{ID: sid, Name: "Alpha"}}}
+	if !reflect.DeepEqual(state.Wings[1], want) {
+		t.Errorf("new wing is %+v, want %+v", state.Wings[1], want)
+	}
+
+	if _, err := api.Fleets.RenameSquad(ctx, 42, sid, "Much too long"); statusOf(err) != http.StatusBadRequest {
+		t.Errorf("RenameSquad with long name returned error %v, want 400", err)
+	}
+
+	if _, err := api.Fleets.DeleteSquad(ctx, 42, sid); err != nil {
+		t.Errorf("DeleteSquad returned error: %v", err)
+	}
+
+	if _, err := api.Fleets.DeleteWing(ctx, 42, wid); err != nil {
+		t.Errorf("DeleteWing returned error: %v", err)
+	}
+
+	if got := len(f.State().Wings); got != 1 {
+		t.Errorf("fleet has %d wings, want 1", got)
+	}
+
+	if _, err := api.Fleets.DeleteWing(ctx, 42, wid); !errors.Is(err, esi.ErrNotFound) {
+		t.Errorf("DeleteWing of deleted wing returned error %v, want ErrNotFound", err)
+	}
+
+	if _, _, err := api.Fleets.CreateSquad(ctx, 42, wid); !errors.Is(err, esi.ErrNotFound) {
+		t.Errorf("CreateSquad in deleted wing returned error %v, want ErrNotFound", err)
+	}
+}
+
+func TestFleet_members(t *testing.T) {
+	s, f, api := newFleet()
+	defer s.Close()
+
+	ctx := context.Background()
+
+	wing := f.State().Wings[0]
+	wid, sid := wing.ID, wing.Squads[0].ID
+
+	invites := []*esi.FleetInvitation{
+		{CharacterID: 2, Role: RoleWingCommander, WingID: wid},
+		{CharacterID: 3, Role: RoleSquadCommander, WingID: wid, SquadID: sid},
+		{CharacterID: 4, Role: RoleSquadMember},
+	}
+	for _, inv := range invites {
+		if _, err := api.Fleets.Invite(ctx, 42, inv); err != nil {
+			t.Fatalf("Invite(%d) returned error: %v", inv.CharacterID, err)
+		}
+	}
+
+	want := []FleetMember{
+		{CharacterID: 1, Role: RoleFleetCommander, WingID: -1, SquadID: -1},
+		{CharacterID: 2, Role: RoleWingCommander, WingID: wid, SquadID: -1},
+		{CharacterID: 3, Role: RoleSquadCommander, WingID: wid, SquadID: sid},
+		{CharacterID: 4, Role: RoleSquadMember, WingID: wid, SquadID: sid},
+	}
+
+	members := f.State().Members
+	if len(members) != len(want) {
+		t.Fatalf("fleet has %d members, want %d", len(members), len(want))
+	}
+
+	for i, m := range members {
+		w := want[i]
+		if m.CharacterID != w.CharacterID || m.Role != w.Role || m.WingID != w.WingID || m.SquadID != w.SquadID {
+			t.Errorf("member %d is %+v, want %+v", i, m, w)
+		}
+	}
+
+	// non-empty squads and wings cannot be deleted
+	if _, err := api.Fleets.DeleteSquad(ctx, 42, sid); statusOf(err) != http.StatusUnprocessableEntity {
+		t.Errorf("DeleteSquad of non-empty squad returned error %v, want 422", err)
+	}
+
+	if _, err := api.Fleets.DeleteWing(ctx, 42, wid); statusOf(err) != http.StatusUnprocessableEntity {
+		t.Errorf("DeleteWing of non-empty wing returned error %v, want 422", err)
+	}
+
+	// move the squad commander to a new squad
+	sid2, _, _ := api.Fleets.CreateSquad(ctx, 42, wid)
+	if _, err := api.Fleets.Move(ctx, 42, 3, &esi.FleetMemberMovement{Role: RoleSquadCommander, WingID: wid, SquadID: sid2}); err != nil {
+		t.Errorf("Move returned error: %v", err)
+	}
+
+	if _, err := api.Fleets.Kick(ctx, 42, 4); err != nil {
+		t.Errorf("Kick returned error: %v", err)
+	}
+
+	if _, err := api.Fleets.Kick(ctx, 42, 4); !errors.Is(err, esi.ErrNotFound) {
+		t.Errorf("Kick of non-member returned error %v, want ErrNotFound", err)
+	}
+
+	// the first squad is now empty
+	if _, err := api.Fleets.DeleteSquad(ctx, 42, sid); err != nil {
+		t.Errorf("DeleteSquad returned error: %v", err)
+	}
+}
+
+func TestFleet_members_empty(t *testing.T) {
+	s, _, api := newFleet()
+	defer s.Close()
+
+	ctx := context.Background()
+
+	if _, err := api.Fleets.Kick(ctx, 42, 1); err != nil {
+		t.Fatalf("Kick returned error: %v", err)
+	}
+
+	req, err := api.NewRequest("GET", "v1/fleets/42/members/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	var body json.RawMessage
+	if _, err := api.Do(ctx, req, &body); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	// an empty fleet has an empty list of members, not null
+	if got := strings.TrimSpace(string(body)); got != "[]" {
+		t.Errorf("members body is %s, want []", got)
+	}
+}
+
+func TestFleet_roleConstraints(t *testing.T) {
+	s, f, api := newFleet()
+	defer s.Close()
+
+	ctx := context.Background()
+
+	wing := f.State().Wings[0]
+	wid, sid := wing.ID, wing.Squads[0].ID
+
+	f.Join(2, RoleWingCommander, wid, 0)
+
+	tests := []struct {
+		inv    esi.FleetInvitation
+		status int
+	}{
+		{esi.FleetInvitation{CharacterID: 3, Role: RoleFleetCommander}, http.StatusUnprocessableEntity},
+		{esi.FleetInvitation{CharacterID: 3, Role: RoleWingCommander, WingID: wid}, http.StatusUnprocessableEntity},
+		{esi.FleetInvitation{CharacterID: 3, Role: RoleWingCommander, WingID: wid, SquadID: sid}, http.StatusUnprocessableEntity},
+		{esi.FleetInvitation{CharacterID: 3, Role: RoleWingCommander, WingID: 9999}, http.StatusNotFound},
+		{esi.FleetInvitation{CharacterID: 3, Role: RoleSquadCommander, WingID: wid}, http.StatusUnprocessableEntity},
+		{esi.FleetInvitation{CharacterID: 3, Role: RoleSquadMember, SquadID: 9999, WingID: wid}, http.StatusNotFound},
+		{esi.FleetInvitation{CharacterID: 3, Role: "admiral"}, http.StatusBadRequest},
+		{esi.FleetInvitation{CharacterID: 2, Role: RoleSquadMember}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		_, err := api.Fleets.Invite(ctx, 42, &tt.inv)
+		if got := statusOf(err); got != tt.status {
+			t.Errorf("Invite(%+v) returned error %v, want status %d", tt.inv, err, tt.status)
+		}
+	}
+
+	if got := len(f.State().Members); got != 2 {
+		t.Errorf("fleet has %d members after invalid invitations, want 2", got)
+	}
+}
+
+func TestFleet_limits(t *testing.T) {
+	s, f, api := newFleet()
+	defer s.Close()
+
+	f.MaxWings = 2
+	f.MaxSquads = 1
+	f.MaxSquadMembers = 2
+	f.MaxMembers = 4
+
+	ctx := context.Background()
+
+	wid, _, err := api.Fleets.CreateWing(ctx, 42)
+	if err != nil {
+		t.Fatalf("CreateWing returned error: %v", err)
+	}
+
+	if _, _, err := api.Fleets.CreateWing(ctx, 42); statusOf(err) != http.StatusUnprocessableEntity {
+		t.Errorf("CreateWing over limit returned error %v, want 422", err)
+	}
+
+	sid, _, err := api.Fleets.CreateSquad(ctx, 42, wid)
+	if err != nil {
+		t.Fatalf("CreateSquad returned error: %v", err)
+	}
+
+	if _, _, err := api.Fleets.CreateSquad(ctx, 42, wid); statusOf(err) != http.StatusUnprocessableEntity {
+		t.Errorf("CreateSquad over limit returned error %v, want 422", err)
+	}
+
+	for cid := 2; cid <= 3; cid++ {
+		if _, err := api.Fleets.Invite(ctx, 42, &esi.FleetInvitation{CharacterID: cid, Role: RoleSquadMember, WingID: wid, SquadID: sid}); err != nil {
+			t.Fatalf("Invite returned error: %v", err)
+		}
+	}
+
+	if _, err := api.Fleets.Invite(ctx, 42, &esi.FleetInvitation{CharacterID: 4, Role: RoleSquadMember, WingID: wid, SquadID: sid}); statusOf(err) != http.StatusUnprocessableEntity {
+		t.Errorf("Invite into full squad returned error %v, want 422", err)
+	}
+
+	// squad members without a position go to a squad with room
+	if _, err := api.Fleets.Invite(ctx, 42, &esi.FleetInvitation{CharacterID: 4, Role: RoleSquadMember}); err != nil {
+		t.Fatalf("Invite returned error: %v", err)
+	}
+
+	if m := f.State().Members[3]; m.SquadID == sid {
+		t.Errorf("member placed in full squad")
+	}
+
+	if _, err := api.Fleets.Invite(ctx, 42, &esi.FleetInvitation{CharacterID: 5, Role: RoleSquadMember}); statusOf(err) != http.StatusUnprocessableEntity {
+		t.Errorf("Invite into full fleet returned error %v, want 422", err)
+	}
+}
+
+func TestFleet_versions(t *testing.T) {
+	s, _, api := newFleet()
+	defer s.Close()
+
+	api.Version = esi.VersionLatest
+	api.Routes = map[string]string{"post_fleets_fleet_id_wings": "v2"}
+
+	ctx := context.Background()
+
+	if _, _, err := api.Fleets.GetWings(ctx, 42, nil); err != nil {
+		t.Fatalf("GetWings returned error: %v", err)
+	}
+
+	if _, _, err := api.Fleets.CreateWing(ctx, 42); err != nil {
+		t.Fatalf("CreateWing returned error: %v", err)
+	}
+
+	fleet, _, err := api.Fleets.GetCharacterFleet(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetCharacterFleet returned error: %v", err)
+	}
+
+	if fleet.FleetID == nil || *fleet.FleetID != 42 {
+		t.Errorf("GetCharacterFleet returned %+v, want fleet 42", fleet)
+	}
+
+	var paths []string
+	for _, r := range s.Requests() {
+		paths = append(paths, r.Path)
+	}
+
+	want := []string{"/latest/fleets/42/wings/", "/v2/fleets/42/wings/", "/latest/characters/1/fleet/"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("server received requests for %q, want %q", paths, want)
+	}
+}
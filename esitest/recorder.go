@@ -0,0 +1,420 @@
+package esitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Redacted replaces secrets in recorded interactions.
+const Redacted = "REDACTED"
+
+// ErrNoInteraction is returned by a replaying Recorder for requests that do
+// not match any recorded interaction.
+var ErrNoInteraction = errors.New("esitest: no recorded interaction")
+
+// A Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay replays the interactions of a cassette without making any
+	// requests.
+	ModeReplay Mode = iota
+
+	// ModeRecord makes real requests and records the interactions.
+	ModeRecord
+)
+
+// A Cassette holds recorded interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// An Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// A RecordedRequest is a recorded request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// A RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded body. It is stored as a string if it is valid UTF-8, and
+// base64 encoded otherwise.
+type Body []byte
+
+// MarshalJSON implements json.Marshaler.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+
+	return json.Marshal(map[string][]byte{"base64": b})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+
+	var v struct {
+		Base64 []byte `json:"base64"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*b = v.Base64
+
+	return nil
+}
+
+// Headers, query parameters and body fields whose values are redacted.
+var (
+	secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+	secretFields  = map[string]bool{
+		"access_token":  true,
+		"client_secret": true,
+		"code":          true,
+		"code_verifier": true,
+		"id_token":      true,
+		"refresh_token": true,
+		"token":         true,
+	}
+)
+
+// A Recorder is an http.RoundTripper that records interactions to a cassette
+// file or replays them. Secrets, such as Authorization headers, access and
+// refresh tokens and authorization codes, are redacted before recording.
+//
+// Requests are matched to interactions by method, path and query. Matching
+// interactions are replayed in the order they were recorded, and the last one
+// is repeated.
+//
+// A test can pick the mode from a flag, so interactions can be recorded once
+// against ESI and replayed offline:
+//
+//	var record = flag.Bool("record", false, "record ESI interactions")
+//
+//	func TestSomething(t *testing.T) {
+//		mode := esitest.ModeReplay
+//		if *record {
+//			mode = esitest.ModeRecord
+//		}
+//
+//		rec, err := esitest.NewRecorder("testdata/something.json", mode)
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		defer rec.Save()
+//
+//		api := esi.NewClient(rec.Client())
+//		...
+//	}
+type Recorder struct {
+	// Transport makes the requests when recording. If nil,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// Scrub, if non-nil, is called for each interaction before it is
+	// recorded, after the built-in redaction. It can redact further secrets.
+	Scrub func(*Interaction)
+
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed map[*Interaction]bool
+}
+
+// NewRecorder returns a Recorder for the cassette file at path. When
+// replaying, the cassette is loaded and must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		mode:     mode,
+		replayed: make(map[*Interaction]bool),
+	}
+
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("esitest: decoding cassette %s: %v", path, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Client returns an HTTP client that uses the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Save writes the recorded interactions to the cassette file. It does nothing
+// when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(&r.cassette, "", "  ")
+	r.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	out := req.Clone(req.Context())
+	if req.Body != nil {
+		out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	it := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    scrubURL(req.URL).String(),
+			Header: scrubHeader(req.Header),
+			Body:   scrubBody(body, req.Header.Get("Content-Type")),
+		},
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: scrubHeader(resp.Header),
+			Body:   scrubBody(respBody, resp.Header.Get("Content-Type")),
+		},
+	}
+
+	if r.Scrub != nil {
+		r.Scrub(it)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, it)
+	r.mu.Unlock()
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	u := scrubURL(req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var last *Interaction
+	for _, it := range r.cassette.Interactions {
+		if !matches(it, req.Method, u) {
+			continue
+		}
+
+		last = it
+		if !r.replayed[it] {
+			break
+		}
+	}
+
+	if last == nil {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, u)
+	}
+
+	r.replayed[last] = true
+
+	header := make(http.Header, len(last.Response.Header))
+	for k, v := range last.Response.Header {
+		header[k] = append([]string(nil), v...)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", last.Response.Status, http.StatusText(last.Response.Status)),
+		StatusCode:    last.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(last.Response.Body)),
+		ContentLength: int64(len(last.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matches reports whether it was recorded for a request with the given
+// method and scrubbed URL.
+func matches(it *Interaction, method string, u *url.URL) bool {
+	if it.Request.Method != method {
+		return false
+	}
+
+	recorded, err := url.Parse(it.Request.URL)
+	if err != nil {
+		return false
+	}
+
+	return recorded.Path == u.Path && recorded.Query().Encode() == u.Query().Encode()
+}
+
+func scrubURL(u *url.URL) *url.URL {
+	v := *u
+
+	q := v.Query()
+	for k := range q {
+		if secretFields[k] {
+			q.Set(k, Redacted)
+		}
+	}
+
+	v.RawQuery = q.Encode()
+	v.User = nil
+
+	return &v
+}
+
+func scrubHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, k := range secretHeaders {
+		if _, ok := h[k]; ok {
+			h.Set(k, Redacted)
+		}
+	}
+
+	return h
+}
+
+// scrubBody redacts secrets in form encoded and JSON bodies.
+func scrubBody(body []byte, contentType string) Body {
+	if len(body) == 0 {
+		return nil
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+
+		for k := range form {
+			if secretFields[k] {
+				form.Set(k, Redacted)
+			}
+		}
+
+		return Body(form.Encode())
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+
+	if !scrubJSON(v) {
+		return body
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+
+	return data
+}
+
+// scrubJSON redacts secret fields in v and reports whether any were found.
+func scrubJSON(v interface{}) bool {
+	scrubbed := false
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, f := range v {
+			if secretFields[k] {
+				v[k] = Redacted
+				scrubbed = true
+			} else if scrubJSON(f) {
+				scrubbed = true
+			}
+		}
+	case []interface{}:
+		for _, f := range v {
+			if scrubJSON(f) {
+				scrubbed = true
+			}
+		}
+	}
+
+	return scrubbed
+}
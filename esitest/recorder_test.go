@@ -0,0 +1,295 @@
+package esitest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"corpus.space/esi"
+)
+
+func tempCassette(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "esitest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, "testdata", "cassette.json"), func() { os.RemoveAll(dir) }
+}
+
+// client returns an esi.Client that uses rec and sends requests to base.
+func client(rec *Recorder, base string) *esi.Client {
+	return clientFor(rec.Client(), base)
+}
+
+// authClient returns an esi.Client that authorizes its requests before they
+// reach rec, as an OAuth2 or SSO client does, and sends them to base.
+func authClient(rec *Recorder, base string) *esi.Client {
+	return clientFor(&http.Client{Transport: &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret-access"}),
+		Base:   rec,
+	}}, base)
+}
+
+func clientFor(httpClient *http.Client, base string) *esi.Client {
+	api := esi.NewClient(httpClient)
+	api.BaseURL, _ = url.Parse(base + "/")
+
+	return api
+}
+
+func TestRecorder(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	s := NewServer()
+	s.Handle("GET", "/v1/fleets/42/", &Response{Body: `{"motd": "hi"}`})
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+
+	api := authClient(rec, s.URL)
+
+	recorded, _, err := api.Fleets.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Fleets.Get returned error: %v", err)
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if got := s.Requests()[0].Header.Get("Authorization"); got != "Bearer secret-access" {
+		t.Errorf("server received Authorization %q", got)
+	}
+
+	s.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(data), "secret-access") {
+		t.Errorf("cassette contains the access token:\n%s", data)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		t.Fatalf("cassette is invalid: %v", err)
+	}
+
+	if got := cassette.Interactions[0].Request.Header.Get("Authorization"); got != Redacted {
+		t.Errorf("recorded Authorization = %q, want %q", got, Redacted)
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+
+	api = authClient(rec, s.URL)
+
+	replayed, resp, err := api.Fleets.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("replayed Fleets.Get returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(replayed, recorded) {
+		t.Errorf("replayed Fleets.Get returned %+v, want %+v", replayed, recorded)
+	}
+
+	if resp.RequestID != "esitest-1" {
+		t.Errorf("replayed RequestID = %q, want esitest-1", resp.RequestID)
+	}
+
+	_, _, err = api.Fleets.Get(context.Background(), 43)
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Fleets.Get of unrecorded fleet returned error %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestRecorder_match(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("GET", "/v1/markets/10000002/orders/", &Response{
+		Pages: []interface{}{
+			[]map[string]int{{"order_id": 1}},
+			[]map[string]int{{"order_id": 2}},
+		},
+	})
+	s.Handle("GET", "/v1/fleets/42/",
+		&Response{Body: `{"motd": "first"}`},
+		&Response{Body: `{"motd": "second"}`},
+	)
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+
+	api := client(rec, s.URL)
+	api.Fleets.Get(context.Background(), 42)
+	api.Fleets.Get(context.Background(), 42)
+	api.Market.ListAllRegionOrders(context.Background(), 10000002, nil)
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+
+	api = client(rec, s.URL)
+
+	// interactions are replayed in order, repeating the last one
+	for _, want := range []string{"first", "second", "second"} {
+		fleet, _, err := api.Fleets.Get(context.Background(), 42)
+		if err != nil {
+			t.Fatalf("Fleets.Get returned error: %v", err)
+		}
+
+		if fleet.MOTD == nil || *fleet.MOTD != want {
+			t.Errorf("Fleets.Get returned %+v, want MOTD %q", fleet, want)
+		}
+	}
+
+	// the query selects the page
+	orders, _, err := api.Market.GetRegionOrders(context.Background(), 10000002, &esi.RegionOrdersOptions{ListOptions: esi.ListOptions{Page: 2}})
+	if err != nil {
+		t.Fatalf("GetRegionOrders returned error: %v", err)
+	}
+
+	if len(orders) != 1 || orders[0].OrderID == nil || *orders[0].OrderID != 2 {
+		t.Errorf("GetRegionOrders returned %+v, want order 2", orders)
+	}
+
+	_, _, err = api.Market.GetRegionOrders(context.Background(), 10000002, &esi.RegionOrdersOptions{ListOptions: esi.ListOptions{Page: 3}})
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("GetRegionOrders of unrecorded page returned error %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestRecorder_scrub(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	s := NewServer()
+	defer s.Close()
+
+	s.HandleFunc("POST", "/v2/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		w.Write([]byte(`{"access_token": "secret-access", "refresh_token": "secret-refresh", "token_type": "Bearer"}`))
+	})
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+
+	rec.Scrub = func(it *Interaction) {
+		it.Request.Header.Del("X-Secret")
+	}
+
+	req, _ := http.NewRequest("POST", s.URL+"/v2/oauth/token?token=secret-query", strings.NewReader(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {"secret-refresh"},
+	}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Secret", "secret-header")
+
+	resp, err := rec.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	// the caller gets the response as received
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if !strings.Contains(string(body), "secret-access") {
+		t.Errorf("response body is %s, want the access token", body)
+	}
+
+	if got := s.Requests()[0].Body; !strings.Contains(string(got), "secret-refresh") {
+		t.Errorf("server received body %s, want the refresh token", got)
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(data), "secret-") {
+		t.Errorf("cassette contains secrets:\n%s", data)
+	}
+
+	if !strings.Contains(string(data), "grant_type=refresh_token") || !strings.Contains(string(data), "token_type") {
+		t.Errorf("cassette lacks non-secret fields:\n%s", data)
+	}
+
+	// requests with secrets in the query still match
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+
+	req, _ = http.NewRequest("POST", s.URL+"/v2/oauth/token?token=other-query", nil)
+
+	resp, err = rec.Client().Do(req)
+	if err != nil {
+		t.Fatalf("replayed Do returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := resp.Header.Get("Set-Cookie"); got != Redacted {
+		t.Errorf("replayed Set-Cookie = %q, want %q", got, Redacted)
+	}
+}
+
+func TestBody_binary(t *testing.T) {
+	want := Body{0xff, 0xd8, 0xff, 0x00}
+
+	data, err := want.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON returned error: %v", err)
+	}
+
+	var got Body
+	if err := got.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Body round trip returned %v, want %v", got, want)
+	}
+}
+
+func TestNewRecorder_missingCassette(t *testing.T) {
+	if _, err := NewRecorder(filepath.Join("testdata", "missing.json"), ModeReplay); !os.IsNotExist(err) {
+		t.Errorf("NewRecorder returned error %v, want a not exist error", err)
+	}
+}
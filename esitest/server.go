@@ -0,0 +1,488 @@
+// Package esitest provides a fake ESI server for testing code that uses the
+// esi package.
+//
+// Canned responses are registered per route, and the server simulates the
+// behaviour of ESI that clients need to handle: pagination, entity tags, the
+// error limit, timeouts and warnings. Fleets can be simulated with NewFleet.
+// All requests received are recorded:
+//
+//	s := esitest.NewServer()
+//	defer s.Close()
+//
+//	s.Handle("GET", "/v1/fleets/42/", &esitest.Response{
+//		Body: map[string]interface{}{"motd": "hi"},
+//	})
+//
+//	api := s.Client()
+//	fleet, _, err := api.Fleets.Get(ctx, 42)
+//
+// Interactions with ESI itself can be recorded and replayed offline with a
+// Recorder.
+package esitest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"corpus.space/esi"
+)
+
+const (
+	// DefaultErrorLimit is the number of errors allowed per error limit
+	// window.
+	DefaultErrorLimit = 100
+
+	// DefaultErrorWindow is the length of an error limit window.
+	DefaultErrorWindow = time.Minute
+)
+
+// Error bodies sent by the server.
+const (
+	notFoundBody     = `{"error": "Requested page does not exist!"}`
+	errorLimitedBody = `{"error": "This software has exceeded the error limit for ESI."}`
+	timeoutBody      = `{"error": "Timeout contacting tranquility", "timeout": 10}`
+)
+
+// A Response is a canned response of a route.
+type Response struct {
+	// Status is the HTTP status code. If zero, 200 OK is used.
+	Status int
+
+	// Body is the body of the response. A string or []byte is sent as is;
+	// any other value is encoded as JSON.
+	Body interface{}
+
+	// Pages holds the bodies of the pages of a paginated route. If set, Body
+	// is ignored, the X-Pages header is sent and the page query parameter
+	// selects the page. Requests for pages out of range get 404 Not Found.
+	Pages []interface{}
+
+	// ETag enables entity tags. The tag is derived from the body; requests
+	// with a matching If-None-Match header get 304 Not Modified.
+	ETag bool
+
+	// Expires is the time for which the response may be cached. If non-zero
+	// the Expires header is sent.
+	Expires time.Duration
+
+	// Warning, if set, is sent in the Warning header, such as
+	//
+	//	299 - "This route is deprecated"
+	Warning string
+
+	// Header holds additional headers to send.
+	Header http.Header
+
+	// Delay is the time to wait before responding. Requests canceled while
+	// waiting get no response.
+	Delay time.Duration
+
+	// Timeout makes the route respond with 504 Gateway Timeout, as ESI does
+	// when its backend times out.
+	Timeout bool
+}
+
+// A Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// A Server is a fake ESI server.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   map[string]*route
+	order    int
+	requests []Request
+	seq      int
+	fleets   map[int]*Fleet
+
+	errorLimit  int
+	errorWindow time.Duration
+	remaining   int
+	windowStart time.Time
+
+	// for testing
+	now func() time.Time
+}
+
+// route holds the registered responses of a route. Responses are served in
+// order; the last one is repeated.
+type route struct {
+	handler   http.Handler
+	responses []*Response
+
+	order     int // registration order
+	wildcards int // number of "*" segments
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when
+// finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		routes:      make(map[string]*route),
+		errorLimit:  DefaultErrorLimit,
+		errorWindow: DefaultErrorWindow,
+		remaining:   DefaultErrorLimit,
+		now:         time.Now,
+	}
+
+	s.windowStart = s.now()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns an esi.Client that makes requests to the server.
+func (s *Server) Client() *esi.Client {
+	api := esi.NewClient(s.Server.Client())
+	api.BaseURL, _ = url.Parse(s.URL + "/")
+
+	return api
+}
+
+// Handle registers responses for requests with the given method and path,
+// such as "GET" and "/v1/fleets/42/". The query is not considered, and a path
+// segment of "*" matches any segment. The responses are served in order, and
+// the last one is served for all further requests. Handle replaces any
+// responses registered earlier.
+//
+// If several patterns match a request, the one with the fewest "*" segments
+// is used, and of those the one registered first.
+func (s *Server) Handle(method, path string, responses ...*Response) {
+	if len(responses) == 0 {
+		panic("esitest: no responses for " + method + " " + path)
+	}
+
+	s.register(method, path, &route{responses: responses})
+}
+
+// HandleFunc registers a handler for requests with the given method and path.
+// The error limit is applied to the responses of the handler.
+func (s *Server) HandleFunc(method, path string, handler func(http.ResponseWriter, *http.Request)) {
+	s.register(method, path, &route{handler: http.HandlerFunc(handler)})
+}
+
+// register registers rt for method and path. A route replacing an earlier one
+// keeps its place in the registration order.
+func (s *Server) register(method, path string, rt *route) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := method + " " + path
+	if old, ok := s.routes[key]; ok {
+		rt.order = old.order
+	} else {
+		s.order++
+		rt.order = s.order
+	}
+
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "*" {
+			rt.wildcards++
+		}
+	}
+
+	s.routes[key] = rt
+}
+
+// route returns the route registered for method and path, preferring exact
+// matches over patterns, and patterns with fewer wildcards over those with
+// more. Ties go to the pattern registered first.
+func (s *Server) route(method, path string) *route {
+	if rt, ok := s.routes[method+" "+path]; ok {
+		return rt
+	}
+
+	var best *route
+
+	segments := strings.Split(method+" "+path, "/")
+	for key, rt := range s.routes {
+		if !matchPattern(strings.Split(key, "/"), segments) {
+			continue
+		}
+
+		if best == nil || rt.wildcards < best.wildcards ||
+			rt.wildcards == best.wildcards && rt.order < best.order {
+			best = rt
+		}
+	}
+
+	return best
+}
+
+func matchPattern(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+
+	for i, p := range pattern {
+		if p != "*" && p != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Requests returns the requests received by the server, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// ClearRequests forgets the requests received so far.
+func (s *Server) ClearRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+}
+
+// SetErrorLimit sets the number of errors allowed per error limit window and
+// the length of the window, and starts a new window.
+func (s *Server) SetErrorLimit(limit int, window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errorLimit = limit
+	s.errorWindow = window
+	s.remaining = limit
+	s.windowStart = s.now()
+}
+
+// SetErrorsRemaining sets the number of errors remaining in the current error
+// limit window. Once no errors remain, all requests get 420 Error Limited
+// until the window resets.
+func (s *Server) SetErrorsRemaining(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resetWindow()
+	s.remaining = n
+}
+
+// ErrorsRemaining returns the number of errors remaining in the current error
+// limit window.
+func (s *Server) ErrorsRemaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resetWindow()
+
+	return s.remaining
+}
+
+// resetWindow starts a new error limit window if the current one has passed.
+func (s *Server) resetWindow() {
+	if elapsed := s.now().Sub(s.windowStart); elapsed >= s.errorWindow {
+		s.windowStart = s.windowStart.Add(elapsed - elapsed%s.errorWindow)
+		s.remaining = s.errorLimit
+	}
+}
+
+// setRateHeaders sets the error limit headers, counting the response as an
+// error if status is an error status.
+func (s *Server) setRateHeaders(w http.ResponseWriter, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resetWindow()
+
+	if status >= 400 && s.remaining > 0 {
+		s.remaining--
+	}
+
+	reset := s.windowStart.Add(s.errorWindow).Sub(s.now())
+	secs := int((reset + time.Second - 1) / time.Second)
+
+	w.Header().Set("X-ESI-Error-Limit-Remain", strconv.Itoa(s.remaining))
+	w.Header().Set("X-ESI-Error-Limit-Reset", strconv.Itoa(secs))
+}
+
+// rateWriter sets the error limit headers when the status is written.
+type rateWriter struct {
+	http.ResponseWriter
+
+	s     *Server
+	wrote bool
+}
+
+func (w *rateWriter) WriteHeader(status int) {
+	if !w.wrote {
+		w.wrote = true
+		w.s.setRateHeaders(w.ResponseWriter, status)
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *rateWriter) Write(b []byte) (int, error) {
+	if !w.wrote {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (s *Server) serveHTTP(rw http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	s.seq++
+	rw.Header().Set("X-ESI-Request-ID", fmt.Sprintf("esitest-%d", s.seq))
+	rw.Header().Set("Date", s.now().UTC().Format(http.TimeFormat))
+
+	s.resetWindow()
+	limited := s.remaining <= 0
+
+	rt := s.route(r.Method, r.URL.Path)
+
+	var resp *Response
+	if rt != nil && rt.handler == nil {
+		resp = rt.responses[0]
+		if len(rt.responses) > 1 {
+			rt.responses = rt.responses[1:]
+		}
+	}
+	s.mu.Unlock()
+
+	w := &rateWriter{ResponseWriter: rw, s: s}
+
+	switch {
+	case limited:
+		writeJSON(w, esi.StatusErrorLimited, errorLimitedBody)
+	case rt == nil:
+		writeJSON(w, http.StatusNotFound, notFoundBody)
+	case rt.handler != nil:
+		rt.handler.ServeHTTP(w, r)
+	default:
+		s.respond(w, r, resp)
+	}
+}
+
+func (s *Server) respond(w http.ResponseWriter, r *http.Request, resp *Response) {
+	if resp.Delay > 0 && !sleep(r.Context(), resp.Delay) {
+		return
+	}
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+
+	if resp.Warning != "" {
+		w.Header().Set("Warning", resp.Warning)
+	}
+
+	if resp.Expires > 0 {
+		w.Header().Set("Expires", s.now().Add(resp.Expires).UTC().Format(http.TimeFormat))
+	}
+
+	if resp.Timeout {
+		writeJSON(w, http.StatusGatewayTimeout, timeoutBody)
+		return
+	}
+
+	v := resp.Body
+	if resp.Pages != nil {
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+
+		if page < 1 || page > len(resp.Pages) {
+			writeJSON(w, http.StatusNotFound, notFoundBody)
+			return
+		}
+
+		w.Header().Set("X-Pages", strconv.Itoa(len(resp.Pages)))
+		v = resp.Pages[page-1]
+	}
+
+	body, err := encode(v)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, fmt.Sprintf(`{"error": %q}`, err.Error()))
+		return
+	}
+
+	if resp.ETag {
+		sum := sha1.Sum(body)
+		etag := `"` + hex.EncodeToString(sum[:]) + `"`
+		w.Header().Set("ETag", etag)
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// encode returns the body of a response holding v.
+func encode(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	}
+
+	return json.Marshal(v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	fmt.Fprint(w, body)
+}
+
+// sleep waits for d and reports whether ctx is still live.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,328 @@
+package esitest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"corpus.space/esi"
+)
+
+func TestServer_Handle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("GET", "/v1/fleets/42/", &Response{Body: `{"motd": "hi"}`})
+
+	api := s.Client()
+
+	fleet, resp, err := api.Fleets.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Fleets.Get returned error: %v", err)
+	}
+
+	if want := (&esi.FleetResponse{MOTD: esi.String("hi")}); !reflect.DeepEqual(fleet, want) {
+		t.Errorf("Fleets.Get returned %+v, want %+v", fleet, want)
+	}
+
+	if resp.RequestID != "esitest-1" {
+		t.Errorf("RequestID = %q, want esitest-1", resp.RequestID)
+	}
+
+	if got := api.Rate().Remaining; got != DefaultErrorLimit {
+		t.Errorf("client error limit remaining = %d, want %d", got, DefaultErrorLimit)
+	}
+}
+
+func TestServer_Requests(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("POST", "/v1/fleets/42/members/", &Response{Status: http.StatusNoContent})
+
+	api := s.Client()
+	api.Datasource = esi.Singularity
+
+	if _, err := api.Fleets.Invite(context.Background(), 42, &esi.FleetInvitation{CharacterID: 43, Role: "squad_member"}); err != nil {
+		t.Fatalf("Fleets.Invite returned error: %v", err)
+	}
+
+	reqs := s.Requests()
+	if len(reqs) != 1 {
+		t.Fatalf("Requests returned %d requests, want 1", len(reqs))
+	}
+
+	r := reqs[0]
+	if r.Method != "POST" || r.Path != "/v1/fleets/42/members/" {
+		t.Errorf("request is %s %s, want POST /v1/fleets/42/members/", r.Method, r.Path)
+	}
+
+	if got := r.Query.Get("datasource"); got != esi.Singularity {
+		t.Errorf("datasource = %q, want %q", got, esi.Singularity)
+	}
+
+	if got := r.Header.Get("User-Agent"); got != esi.DefaultUserAgent {
+		t.Errorf("User-Agent = %q, want %q", got, esi.DefaultUserAgent)
+	}
+
+	if got, want := string(r.Body), `{"character_id":43,"role":"squad_member"}`+"\n"; got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+
+	s.ClearRequests()
+	if len(s.Requests()) != 0 {
+		t.Errorf("Requests returned requests after ClearRequests")
+	}
+}
+
+func TestServer_pages(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("GET", "/v1/markets/10000002/orders/", &Response{
+		Pages: []interface{}{
+			[]map[string]int{{"order_id": 1}, {"order_id": 2}},
+			[]map[string]int{{"order_id": 3}},
+			[]map[string]int{{"order_id": 4}},
+		},
+	})
+
+	orders, err := s.Client().Market.ListAllRegionOrders(context.Background(), 10000002, nil)
+	if err != nil {
+		t.Fatalf("ListAllRegionOrders returned error: %v", err)
+	}
+
+	var ids []int64
+	for _, o := range orders {
+		ids = append(ids, *o.OrderID)
+	}
+
+	if want := []int64{1, 2, 3, 4}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ListAllRegionOrders returned orders %v, want %v", ids, want)
+	}
+
+	_, _, err = s.Client().Market.GetRegionOrders(context.Background(), 10000002, &esi.RegionOrdersOptions{ListOptions: esi.ListOptions{Page: 4}})
+	if !errors.Is(err, esi.ErrNotFound) {
+		t.Errorf("GetRegionOrders out of range returned error %v, want ErrNotFound", err)
+	}
+}
+
+func TestServer_etag(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("GET", "/v3/alliances/42/", &Response{Body: `{"name": "Test Alliance"}`, ETag: true, Expires: time.Minute})
+
+	api := s.Client()
+	api.ETags = esi.NewMemoryETagStore()
+
+	_, resp, err := api.Alliances.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Alliances.Get returned error: %v", err)
+	}
+
+	if resp.ETag == "" || resp.Expires.IsZero() {
+		t.Errorf("response has no ETag or Expires header")
+	}
+
+	alliance, resp, err := api.Alliances.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Alliances.Get returned error: %v", err)
+	}
+
+	if !resp.NotModified {
+		t.Errorf("second response is not 304 Not Modified")
+	}
+
+	if alliance.Name == nil || *alliance.Name != "Test Alliance" {
+		t.Errorf("Alliances.Get returned %+v from the ETag store", alliance)
+	}
+
+	if got := s.Requests()[1].Header.Get("If-None-Match"); got != resp.ETag {
+		t.Errorf("If-None-Match = %q, want %q", got, resp.ETag)
+	}
+}
+
+func TestServer_errorLimit(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	s.SetErrorLimit(10, time.Minute)
+
+	api := s.Client()
+	api.ErrorLimitThreshold = 0
+
+	// unregistered routes are errors
+	_, _, err := api.Fleets.Get(context.Background(), 42)
+	if !errors.Is(err, esi.ErrNotFound) {
+		t.Fatalf("Fleets.Get returned error %v, want ErrNotFound", err)
+	}
+
+	if got := s.ErrorsRemaining(); got != 9 {
+		t.Errorf("ErrorsRemaining = %d, want 9", got)
+	}
+
+	if rate := api.Rate(); rate.Remaining != 9 || !rate.Reset.After(time.Now().Add(59*time.Second)) {
+		t.Errorf("client rate is %v", rate)
+	}
+
+	s.SetErrorsRemaining(1)
+	api.Fleets.Get(context.Background(), 42)
+
+	_, _, err = api.Fleets.Get(context.Background(), 42)
+	var lerr *esi.ErrorLimitedError
+	if !errors.As(err, &lerr) {
+		t.Fatalf("Fleets.Get returned error %v, want *ErrorLimitedError", err)
+	}
+
+	// the ban lifts with the next window
+	now = now.Add(time.Minute)
+	s.Handle("GET", "/v1/fleets/42/", &Response{Body: `{}`})
+
+	if _, _, err := api.Fleets.Get(context.Background(), 42); err != nil {
+		t.Errorf("Fleets.Get returned error: %v", err)
+	}
+
+	if got := s.ErrorsRemaining(); got != 10 {
+		t.Errorf("ErrorsRemaining = %d, want 10", got)
+	}
+}
+
+func TestServer_timeout(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("GET", "/v1/fleets/42/", &Response{Timeout: true})
+
+	_, _, err := s.Client().Fleets.Get(context.Background(), 42)
+	if !errors.Is(err, esi.ErrTimeout) {
+		t.Errorf("Fleets.Get returned error %v, want ErrTimeout", err)
+	}
+}
+
+func TestServer_sequence(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("GET", "/v1/fleets/42/",
+		&Response{Status: http.StatusBadGateway, Body: `{"error": "bad gateway"}`},
+		&Response{Body: `{"motd": "hi"}`},
+	)
+
+	api := s.Client()
+	api.Retry = &esi.RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	fleet, _, err := api.Fleets.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Fleets.Get returned error: %v", err)
+	}
+
+	if fleet.MOTD == nil || *fleet.MOTD != "hi" {
+		t.Errorf("Fleets.Get returned %+v", fleet)
+	}
+
+	// the last response is repeated
+	if _, _, err := api.Fleets.Get(context.Background(), 42); err != nil {
+		t.Errorf("Fleets.Get returned error: %v", err)
+	}
+
+	if got := len(s.Requests()); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+}
+
+func TestServer_warning(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("GET", "/v1/fleets/42/", &Response{Body: `{}`, Warning: `299 - "This route is deprecated"`})
+
+	api := s.Client()
+
+	var mu sync.Mutex
+	var deprecated []esi.Warning
+	api.OnDeprecation = func(resp *esi.Response, w esi.Warning) {
+		mu.Lock()
+		deprecated = append(deprecated, w)
+		mu.Unlock()
+	}
+
+	if _, _, err := api.Fleets.Get(context.Background(), 42); err != nil {
+		t.Fatalf("Fleets.Get returned error: %v", err)
+	}
+
+	if len(deprecated) != 1 || deprecated[0].Message != "This route is deprecated" {
+		t.Errorf("deprecation warnings are %v", deprecated)
+	}
+}
+
+func TestServer_delay(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("GET", "/v1/fleets/42/", &Response{Body: `{}`, Delay: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := s.Client().Fleets.Get(ctx, 42); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Fleets.Get returned error %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestServer_HandleFunc(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.HandleFunc("GET", "/v1/fleets/42/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "forbidden"}`, http.StatusForbidden)
+	})
+
+	_, _, err := s.Client().Fleets.Get(context.Background(), 42)
+	if !errors.Is(err, esi.ErrForbidden) {
+		t.Errorf("Fleets.Get returned error %v, want ErrForbidden", err)
+	}
+
+	if got := s.ErrorsRemaining(); got != DefaultErrorLimit-1 {
+		t.Errorf("ErrorsRemaining = %d, want %d", got, DefaultErrorLimit-1)
+	}
+}
+
+func TestServer_patterns(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Handle("GET", "/v1/characters/*/*/", &Response{Body: `{"name": "any"}`})
+	s.Handle("GET", "/v1/*/42/", &Response{Body: `{"name": "first"}`})
+	s.Handle("GET", "/v1/characters/*/", &Response{Body: `{"name": "second"}`})
+	s.Handle("GET", "/v1/characters/*/fleet/", &Response{Body: `{"fleet_id": 7}`})
+
+	api := s.Client()
+
+	// map iteration order must not affect the outcome
+	for i := 0; i < 20; i++ {
+		character, _, err := api.Characters.GetCharacter(context.Background(), 42)
+		if err != nil {
+			t.Fatalf("Characters.GetCharacter returned error: %v", err)
+		}
+
+		if character.Name == nil || *character.Name != "first" {
+			t.Fatalf("Characters.GetCharacter returned %+v, want the route registered first", character)
+		}
+
+		fleet, _, err := api.Fleets.GetCharacterFleet(context.Background(), 42)
+		if err != nil {
+			t.Fatalf("Fleets.GetCharacterFleet returned error: %v", err)
+		}
+
+		if fleet.FleetID == nil || *fleet.FleetID != 7 {
+			t.Fatalf("Fleets.GetCharacterFleet returned %+v, want the route with fewer wildcards", fleet)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package esi
+
+import "sync"
+
+// An ETagEntry is a cached response body along with the entity tag ESI
+// returned for it.
+type ETagEntry struct {
+	ETag string
+	Body []byte
+}
+
+// An ETagStore stores entity tags and response bodies for previously fetched
+// URLs. Implementations must be safe for concurrent use.
+//
+// When a store is configured on the Client, GET requests for URLs with a
+// stored entry are sent with an If-None-Match header. If ESI responds with
+// 304 Not Modified, the stored body is used in place of the (empty) response
+// body.
+type ETagStore interface {
+	// Get returns the entry stored for url, if any.
+	Get(url string) (*ETagEntry, bool)
+
+	// Set stores entry for url, replacing any existing entry.
+	Set(url string, entry *ETagEntry)
+}
+
+type memoryETagStore struct {
+	mu      sync.RWMutex
+	entries map[string]*ETagEntry
+}
+
+// NewMemoryETagStore returns an ETagStore that keeps entries in memory. Entries
+// are never evicted.
+func NewMemoryETagStore() ETagStore {
+	return &memoryETagStore{entries: make(map[string]*ETagEntry)}
+}
+
+func (s *memoryETagStore) Get(url string) (*ETagEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.entries[url]
+	return entry, ok
+}
+
+func (s *memoryETagStore) Set(url string, entry *ETagEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[url] = entry
+}
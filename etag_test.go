@@ -0,0 +1,173 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDo_etagNotModified(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.ETags = NewMemoryETagStore()
+
+	type foo struct {
+		A string
+	}
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"abc"`)
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	body := new(foo)
+	resp, err := client.Do(context.Background(), req, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.NotModified {
+		t.Error("expected NotModified to be false on first request")
+	}
+
+	req, _ = client.NewRequest("GET", ".", nil)
+	body = new(foo)
+	resp, err = client.Do(context.Background(), req, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.NotModified {
+		t.Error("expected NotModified to be true on second request")
+	}
+
+	if want := (&foo{"a"}); !reflect.DeepEqual(body, want) {
+		t.Errorf("Response body = %v, want %v", body, want)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected 2 calls to the server; got %d", calls)
+	}
+}
+
+func TestDo_etagNotStoredForOtherMethods(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.ETags = NewMemoryETagStore()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"abc"`)
+	})
+
+	req, _ := client.NewRequest("PUT", ".", nil)
+	client.Do(context.Background(), req, nil)
+
+	if _, ok := client.ETags.Get(req.URL.String()); ok {
+		t.Fatal("expected no entry for PUT request")
+	}
+}
+
+func TestDo_notModifiedWithoutStore(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestMemoryETagStore(t *testing.T) {
+	s := NewMemoryETagStore()
+
+	if _, ok := s.Get("foo"); ok {
+		t.Fatal("expected no entry")
+	}
+
+	want := &ETagEntry{ETag: `"abc"`, Body: []byte("data")}
+	s.Set("foo", want)
+
+	got, ok := s.Get("foo")
+	if !ok {
+		t.Fatal("expected entry")
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Get returned %+v, want %+v", got, want)
+	}
+}
+
+func TestDo_etagRequestReused(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.ETags = NewMemoryETagStore()
+	client.Retry = &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if calls == 2 {
+			http.Error(w, `{"error":"Bad gateway"}`, 502)
+			return
+		}
+
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"abc"`)
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+
+	// the second use of req is retried after a 502
+	for i := 0; i < 3; i++ {
+		body := new(struct{ A string })
+		resp, err := client.Do(context.Background(), req, body)
+		if err != nil {
+			t.Fatalf("Do #%d returned error: %v", i+1, err)
+		}
+
+		if resp.NotModified != (i > 0) {
+			t.Errorf("Do #%d returned NotModified %v", i+1, resp.NotModified)
+		}
+
+		if body.A != "a" {
+			t.Errorf("Do #%d returned body %+v", i+1, body)
+		}
+
+		if v := req.Header.Get("If-None-Match"); v != "" {
+			t.Errorf("Do #%d set If-None-Match %q on the request", i+1, v)
+		}
+	}
+
+	if calls != 4 {
+		t.Errorf("expected 4 calls to the server; got %d", calls)
+	}
+}
@@ -3,16 +3,13 @@ package esi_test
 /*
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 
-	"golang.org/x/oauth2"
-
 	"github.com/gregjones/httpcache"
 
 	"corpus.space/esi"
+	"corpus.space/esi/sso"
 )
 
 const PascalCharacterID = 440659656
@@ -61,22 +58,16 @@ func ExampleConfig_custom_transport() {
 func ExampleConfig_authenticated() {
 	ctx := context.Background()
 
-	oauth2conf := &oauth2.Config{
-		ClientID:     "YOUR_CLIENT_ID",
-		ClientSecret: "YOUR_CLIENT_SECRET",
-
-		Scopes: []string{
-			"esi-wallet.read_character_wallet.v1",
-		},
+	config := sso.NewConfig("YOUR_CLIENT_ID", "", "http://localhost:8080/callback",
+		"esi-wallet.read_character_wallet.v1",
+	)
 
-		Endpoint: oauth2.Endpoint{
-			AuthURL:  "https://login.eveonline.com/oauth/authorize",
-			TokenURL: "https://login.eveonline.com/oauth/token",
-		},
+	login, err := config.Begin()
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	url := oauth2conf.AuthCodeURL("state", oauth2.AccessTypeOffline)
-	fmt.Printf("Visit the URL for the auth dialog: %v", url)
+	fmt.Printf("Visit the URL for the auth dialog: %v", login.URL)
 
 	fmt.Printf("Code: ")
 	var code string
@@ -84,45 +75,21 @@ func ExampleConfig_authenticated() {
 		log.Fatal(err)
 	}
 
-	tok, err := oauth2conf.Exchange(ctx, code)
+	tok, err := config.Complete(ctx, login, login.State, code)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if !tok.Valid() {
-		log.Fatal("invalid token received")
-	}
-
-	authenticatedClient := oauth2conf.Client(ctx, tok)
-
 	// check and store who we authenticated as
-	resp, err := authenticatedClient.Get("https://login.eveonline.com/oauth/verify")
+	claims, err := config.Verifier().Verify(ctx, tok.AccessToken)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	buf, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		log.Fatal(err)
-	}
+	api := esi.NewClient(config.Client(ctx, tok))
 
-	var info struct {
-		ID        int    `json:"CharacterID"`
-		OwnerHash string `json:"CharacterOwnerHash"`
-	}
-
-	if err := json.Unmarshal(buf, &info); err != nil {
-		log.Fatal(err)
-		return
-	}
-
-	ctx = context.WithValue(ctx,
-		esi.HTTPClient, oauth2conf.Client(ctx, tok),
-	)
-
-	var balance float64
-
-	if err := esi.Get(ctx, &balance, "/v1/characters/%d/wallet/", info.ID); err != nil {
+	balance, _, err := api.Wallet.GetCharacterBalance(ctx, claims.CharacterID)
+	if err != nil {
 		log.Fatal(err)
 	}
 
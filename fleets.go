@@ -1,9 +1,6 @@
 package esi
 
-import (
-	"context"
-	"fmt"
-)
+import "context"
 
 // FleetsEndpoint handles communication with the fleets related methods of
 // the ESI API.
@@ -19,7 +16,7 @@ type CharacterFleetResponse struct {
 
 // GetCharacterFleet returns the fleet ID the is in, if any.
 func (e *FleetsEndpoint) GetCharacterFleet(ctx context.Context, cid int) (*CharacterFleetResponse, *Response, error) {
-	u := fmt.Sprintf("v1/characters/%d/fleet/", cid)
+	u := e.api.route("get_characters_character_id_fleet", cid)
 
 	req, err := e.api.NewRequest("GET", u, nil)
 	if err != nil {
@@ -45,7 +42,7 @@ type FleetResponse struct {
 
 // Get returns details about a fleet.
 func (e *FleetsEndpoint) Get(ctx context.Context, fid int) (*FleetResponse, *Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/", fid)
+	u := e.api.route("get_fleets_fleet_id", fid)
 
 	req, err := e.api.NewRequest("GET", u, nil)
 	if err != nil {
@@ -69,7 +66,7 @@ type FleetSettings struct {
 
 // Update updates settings about a fleet.
 func (e *FleetsEndpoint) Update(ctx context.Context, fid int, settings *FleetSettings) (*Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/", fid)
+	u := e.api.route("put_fleets_fleet_id", fid)
 
 	req, err := e.api.NewRequest("PUT", u, settings)
 	if err != nil {
@@ -85,7 +82,7 @@ type FleetMember struct {
 	JoinTime       *Timestamp `json:"join_time,omitempty"`
 	Role           *string    `json:"role,omitempty"`
 	RoleName       *string    `json:"role_name,omitempty"`
-	ShipTypeID     *string    `json:"ship_type_id,omitempty"`
+	ShipTypeID     *int       `json:"ship_type_id,omitempty"`
 	SolarSystemID  *int       `json:"solar_system_id,omitempty"`
 	SquadID        *int       `json:"squad_id,omitempty"`
 	StationID      *int       `json:"station_id,omitempty"`
@@ -98,7 +95,7 @@ type FleetMembersResponse []*FleetMember
 
 // GetMembers returns information about fleet members.
 func (e *FleetsEndpoint) GetMembers(ctx context.Context, fid int, opt *I18NOptions) (FleetMembersResponse, *Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/members/", fid)
+	u := e.api.route("get_fleets_fleet_id_members", fid)
 	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
@@ -129,7 +126,7 @@ type FleetInvitation struct {
 // Invite invites a character into the fleet. If a character has a CSPA charge
 // set it is not possible to invite them to the fleet using ESI.
 func (e *FleetsEndpoint) Invite(ctx context.Context, fid int, invitation *FleetInvitation) (*Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/members/", fid)
+	u := e.api.route("post_fleets_fleet_id_members", fid)
 
 	req, err := e.api.NewRequest("POST", u, invitation)
 	if err != nil {
@@ -141,7 +138,7 @@ func (e *FleetsEndpoint) Invite(ctx context.Context, fid int, invitation *FleetI
 
 // Kick kicks a fleet member.
 func (e *FleetsEndpoint) Kick(ctx context.Context, fid int, cid int) (*Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/members/%d/", fid, cid)
+	u := e.api.route("delete_fleets_fleet_id_members_member_id", fid, cid)
 
 	req, err := e.api.NewRequest("DELETE", u, nil)
 	if err != nil {
@@ -160,7 +157,7 @@ type FleetMemberMovement struct {
 
 // Move moves a fleet member between squads and wings.
 func (e *FleetsEndpoint) Move(ctx context.Context, fid int, cid int, m *FleetMemberMovement) (*Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/members/%d/", fid, cid)
+	u := e.api.route("put_fleets_fleet_id_members_member_id", fid, cid)
 
 	req, err := e.api.NewRequest("PUT", u, m)
 	if err != nil {
@@ -172,7 +169,7 @@ func (e *FleetsEndpoint) Move(ctx context.Context, fid int, cid int, m *FleetMem
 
 // DeleteSquad deletes a fleet squad. Only empty squads can be deleted.
 func (e *FleetsEndpoint) DeleteSquad(ctx context.Context, fid int, sid int) (*Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/squads/%d/", fid, sid)
+	u := e.api.route("delete_fleets_fleet_id_squads_squad_id", fid, sid)
 
 	req, err := e.api.NewRequest("DELETE", u, nil)
 	if err != nil {
@@ -184,7 +181,7 @@ func (e *FleetsEndpoint) DeleteSquad(ctx context.Context, fid int, sid int) (*Re
 
 // RenameSquad renames a fleet squad.
 func (e *FleetsEndpoint) RenameSquad(ctx context.Context, fid int, sid int, name string) (*Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/squads/%d/", fid, sid)
+	u := e.api.route("put_fleets_fleet_id_squads_squad_id", fid, sid)
 
 	req, err := e.api.NewRequest("PUT", u, struct {
 		Name string `json:"name"`
@@ -214,7 +211,7 @@ type FleetWingsResponse []*FleetWing
 
 // GetWings returns information about wings in a fleet.
 func (e *FleetsEndpoint) GetWings(ctx context.Context, fid int, opt *I18NOptions) (FleetWingsResponse, *Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/wings/", fid)
+	u := e.api.route("get_fleets_fleet_id_wings", fid)
 	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
@@ -236,9 +233,9 @@ func (e *FleetsEndpoint) GetWings(ctx context.Context, fid int, opt *I18NOptions
 
 // CreateWing creates a new wing in a fleet.
 func (e *FleetsEndpoint) CreateWing(ctx context.Context, fid int) (int, *Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/wings/", fid)
+	u := e.api.route("post_fleets_fleet_id_wings", fid)
 
-	req, err := e.api.NewRequest("PUT", u, nil)
+	req, err := e.api.NewRequest("POST", u, nil)
 	if err != nil {
 		return -1, nil, err
 	}
@@ -258,7 +255,7 @@ func (e *FleetsEndpoint) CreateWing(ctx context.Context, fid int) (int, *Respons
 // DeleteWing deletes a wing in a fleet. Only empty wings may be deleted. The
 // wing may contain squads but the squads must be empty.
 func (e *FleetsEndpoint) DeleteWing(ctx context.Context, fid int, wid int) (*Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/wings/%d/", fid, wid)
+	u := e.api.route("delete_fleets_fleet_id_wings_wing_id", fid, wid)
 
 	req, err := e.api.NewRequest("DELETE", u, nil)
 	if err != nil {
@@ -270,7 +267,7 @@ func (e *FleetsEndpoint) DeleteWing(ctx context.Context, fid int, wid int) (*Res
 
 // RenameWing renames a fleet wing.
 func (e *FleetsEndpoint) RenameWing(ctx context.Context, fid int, wid int, name string) (*Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/wings/%d/", wid, wid)
+	u := e.api.route("put_fleets_fleet_id_wings_wing_id", fid, wid)
 
 	req, err := e.api.NewRequest("PUT", u, struct {
 		Name string `json:"name"`
@@ -282,11 +279,11 @@ func (e *FleetsEndpoint) RenameWing(ctx context.Context, fid int, wid int, name
 	return e.api.Do(ctx, req, nil)
 }
 
-// CreateSquad creates a new squad in a fleet.
-func (e *FleetsEndpoint) CreateSquad(ctx context.Context, fid int) (int, *Response, error) {
-	u := fmt.Sprintf("v1/fleets/%d/wings/squads/", fid)
+// CreateSquad creates a new squad in a wing of a fleet.
+func (e *FleetsEndpoint) CreateSquad(ctx context.Context, fid int, wid int) (int, *Response, error) {
+	u := e.api.route("post_fleets_fleet_id_wings_wing_id_squads", fid, wid)
 
-	req, err := e.api.NewRequest("PUT", u, nil)
+	req, err := e.api.NewRequest("POST", u, nil)
 	if err != nil {
 		return -1, nil, err
 	}
@@ -72,6 +72,30 @@ func TestFleetsEndpoint_GetFleetInfo(t *testing.T) {
 	}
 }
 
+func TestFleetsEndpoint_GetMembers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/fleets/42/members/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"character_id": 93265215, "ship_type_id": 33328, "solar_system_id": 30003729}]`)
+	})
+
+	members, _, err := client.Fleets.GetMembers(context.Background(), 42, nil)
+	if err != nil {
+		t.Fatalf("Fleets.GetMembers returned error: %v", err)
+	}
+
+	want := FleetMembersResponse{{
+		CharacterID:   Int(93265215),
+		ShipTypeID:    Int(33328),
+		SolarSystemID: Int(30003729),
+	}}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("Fleets.GetMembers returned %+v, want %+v", members, want)
+	}
+}
+
 func TestFleetsEndpoint_Update(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -89,3 +113,55 @@ func TestFleetsEndpoint_Update(t *testing.T) {
 		t.Errorf("Fleets.Update returned error: %v", err)
 	}
 }
+
+func TestFleetsEndpoint_RenameWing(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/fleets/42/wings/7/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"name":"some name"}`+"\n")
+	})
+
+	if _, err := client.Fleets.RenameWing(context.Background(), 42, 7, "some name"); err != nil {
+		t.Errorf("Fleets.RenameWing returned error: %v", err)
+	}
+}
+
+func TestFleetsEndpoint_CreateSquad(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/fleets/42/wings/7/squads/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"squad_id": 123}`)
+	})
+
+	sid, _, err := client.Fleets.CreateSquad(context.Background(), 42, 7)
+	if err != nil {
+		t.Errorf("Fleets.CreateSquad returned error: %v", err)
+	}
+
+	if sid != 123 {
+		t.Errorf("Fleets.CreateSquad returned %d, want 123", sid)
+	}
+}
+
+func TestFleetsEndpoint_CreateWing(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/fleets/42/wings/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"wing_id": 7}`)
+	})
+
+	wid, _, err := client.Fleets.CreateWing(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Fleets.CreateWing returned error: %v", err)
+	}
+
+	if wid != 7 {
+		t.Errorf("Fleets.CreateWing returned %d, want 7", wid)
+	}
+}
@@ -0,0 +1,406 @@
+package esi
+
+import "context"
+
+// MarketEndpoint handles communication with the market related methods of the
+// ESI API.
+type MarketEndpoint endpoint
+
+// OrderType selects the kind of orders returned from a regional market.
+type OrderType string
+
+// Market order types.
+const (
+	OrderTypeAll  OrderType = "all"
+	OrderTypeBuy  OrderType = "buy"
+	OrderTypeSell OrderType = "sell"
+)
+
+// MarketOrder holds an order on a regional or structure market.
+type MarketOrder struct {
+	Duration     *int       `json:"duration,omitempty"`
+	IsBuyOrder   *bool      `json:"is_buy_order,omitempty"`
+	Issued       *Timestamp `json:"issued,omitempty"`
+	LocationID   *int64     `json:"location_id,omitempty"`
+	MinVolume    *int       `json:"min_volume,omitempty"`
+	OrderID      *int64     `json:"order_id,omitempty"`
+	Price        *float64   `json:"price,omitempty"`
+	Range        *string    `json:"range,omitempty"`
+	SystemID     *int       `json:"system_id,omitempty"`
+	TypeID       *int       `json:"type_id,omitempty"`
+	VolumeRemain *int       `json:"volume_remain,omitempty"`
+	VolumeTotal  *int       `json:"volume_total,omitempty"`
+}
+
+func (s MarketOrder) String() string {
+	return Stringify(s)
+}
+
+// MarketOrdersResponse holds a list of market orders.
+type MarketOrdersResponse []*MarketOrder
+
+// RegionOrdersOptions specifies the optional parameters to the
+// MarketEndpoint.GetRegionOrders method.
+type RegionOrdersOptions struct {
+	ListOptions
+
+	// OrderType selects buy orders, sell orders or both. If empty,
+	// OrderTypeAll is used.
+	OrderType OrderType `url:"order_type"`
+
+	// TypeID only returns orders for the given type.
+	TypeID int `url:"type_id,omitempty"`
+}
+
+// GetRegionOrders returns a page of the orders in a region.
+func (e *MarketEndpoint) GetRegionOrders(ctx context.Context, rid int, opt *RegionOrdersOptions) (MarketOrdersResponse, *Response, error) {
+	var o RegionOrdersOptions
+	if opt != nil {
+		o = *opt
+	}
+
+	if o.OrderType == "" {
+		o.OrderType = OrderTypeAll
+	}
+
+	u := e.api.route("get_markets_region_id_orders", rid)
+	u, err := addOptions(u, o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var marketOrdersResponse MarketOrdersResponse
+	resp, err := e.api.Do(ctx, req, &marketOrdersResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return marketOrdersResponse, resp, nil
+}
+
+// ListAllRegionOrders returns the orders on all pages of a region. The page
+// option is ignored. Pages after the first are fetched concurrently, as
+// limited by the client's MaxConcurrentPages.
+func (e *MarketEndpoint) ListAllRegionOrders(ctx context.Context, rid int, opt *RegionOrdersOptions) (MarketOrdersResponse, error) {
+	var o RegionOrdersOptions
+	if opt != nil {
+		o = *opt
+	}
+
+	it := e.api.NewPageIterator(ctx, func(ctx context.Context, page int) (interface{}, *Response, error) {
+		o := o
+		o.Page = page
+
+		return e.GetRegionOrders(ctx, rid, &o)
+	})
+	defer it.Close()
+
+	var orders MarketOrdersResponse
+	for it.Next() {
+		orders = append(orders, it.Item().(*MarketOrder))
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// MarketHistory holds the market statistics of a type in a region for a
+// single day.
+type MarketHistory struct {
+	Average    *float64 `json:"average,omitempty"`
+	Date       *Date    `json:"date,omitempty"`
+	Highest    *float64 `json:"highest,omitempty"`
+	Lowest     *float64 `json:"lowest,omitempty"`
+	OrderCount *int64   `json:"order_count,omitempty"`
+	Volume     *int64   `json:"volume,omitempty"`
+}
+
+func (s MarketHistory) String() string {
+	return Stringify(s)
+}
+
+// MarketHistoryResponse holds a list of daily market statistics.
+type MarketHistoryResponse []*MarketHistory
+
+// GetRegionHistory returns the daily market statistics of a type in a region.
+func (e *MarketEndpoint) GetRegionHistory(ctx context.Context, rid int, typeID int) (MarketHistoryResponse, *Response, error) {
+	u := e.api.route("get_markets_region_id_history", rid)
+	u, err := addOptions(u, struct {
+		TypeID int `url:"type_id"`
+	}{typeID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var marketHistoryResponse MarketHistoryResponse
+	resp, err := e.api.Do(ctx, req, &marketHistoryResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return marketHistoryResponse, resp, nil
+}
+
+// GetRegionTypes returns a page of the IDs of the types with active orders in
+// a region.
+func (e *MarketEndpoint) GetRegionTypes(ctx context.Context, rid int, opt *ListOptions) ([]int, *Response, error) {
+	u := e.api.route("get_markets_region_id_types", rid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// MarketPrice holds the global average and adjusted price of a type.
+type MarketPrice struct {
+	AdjustedPrice *float64 `json:"adjusted_price,omitempty"`
+	AveragePrice  *float64 `json:"average_price,omitempty"`
+	TypeID        *int     `json:"type_id,omitempty"`
+}
+
+func (s MarketPrice) String() string {
+	return Stringify(s)
+}
+
+// MarketPricesResponse holds a list of market prices.
+type MarketPricesResponse []*MarketPrice
+
+// GetPrices returns the global average and adjusted prices of all types.
+func (e *MarketEndpoint) GetPrices(ctx context.Context) (MarketPricesResponse, *Response, error) {
+	u := e.api.route("get_markets_prices")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var marketPricesResponse MarketPricesResponse
+	resp, err := e.api.Do(ctx, req, &marketPricesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return marketPricesResponse, resp, nil
+}
+
+// ListGroups returns the IDs of all market groups.
+func (e *MarketEndpoint) ListGroups(ctx context.Context) ([]int, *Response, error) {
+	u := e.api.route("get_markets_groups")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// MarketGroup holds details of a market group.
+type MarketGroup struct {
+	Description   *string `json:"description,omitempty"`
+	MarketGroupID *int    `json:"market_group_id,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	ParentGroupID *int    `json:"parent_group_id,omitempty"`
+	Types         []int   `json:"types,omitempty"`
+}
+
+func (s MarketGroup) String() string {
+	return Stringify(s)
+}
+
+// GetGroup returns details of a market group.
+func (e *MarketEndpoint) GetGroup(ctx context.Context, gid int, opt *I18NOptions) (*MarketGroup, *Response, error) {
+	u := e.api.route("get_markets_groups_market_group_id", gid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	marketGroup := new(MarketGroup)
+	resp, err := e.api.Do(ctx, req, marketGroup)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return marketGroup, resp, nil
+}
+
+// GetStructureOrders returns a page of the orders in a structure. The
+// character must have access to the structure market.
+func (e *MarketEndpoint) GetStructureOrders(ctx context.Context, sid int64, opt *ListOptions) (MarketOrdersResponse, *Response, error) {
+	u := e.api.route("get_markets_structures_structure_id", sid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var marketOrdersResponse MarketOrdersResponse
+	resp, err := e.api.Do(ctx, req, &marketOrdersResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return marketOrdersResponse, resp, nil
+}
+
+// CharacterOrder holds a market order placed by a character.
+type CharacterOrder struct {
+	Duration      *int       `json:"duration,omitempty"`
+	Escrow        *float64   `json:"escrow,omitempty"`
+	IsBuyOrder    *bool      `json:"is_buy_order,omitempty"`
+	IsCorporation *bool      `json:"is_corporation,omitempty"`
+	Issued        *Timestamp `json:"issued,omitempty"`
+	LocationID    *int64     `json:"location_id,omitempty"`
+	MinVolume     *int       `json:"min_volume,omitempty"`
+	OrderID       *int64     `json:"order_id,omitempty"`
+	Price         *float64   `json:"price,omitempty"`
+	Range         *string    `json:"range,omitempty"`
+	RegionID      *int       `json:"region_id,omitempty"`
+	State         *string    `json:"state,omitempty"`
+	TypeID        *int       `json:"type_id,omitempty"`
+	VolumeRemain  *int       `json:"volume_remain,omitempty"`
+	VolumeTotal   *int       `json:"volume_total,omitempty"`
+}
+
+func (s CharacterOrder) String() string {
+	return Stringify(s)
+}
+
+// CharacterOrdersResponse holds a list of character market orders.
+type CharacterOrdersResponse []*CharacterOrder
+
+// GetCharacterOrders returns the open market orders of a character.
+func (e *MarketEndpoint) GetCharacterOrders(ctx context.Context, cid int) (CharacterOrdersResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_orders", cid)
+	return e.getCharacterOrders(ctx, u)
+}
+
+// GetCharacterOrderHistory returns a page of the expired and cancelled market
+// orders of a character.
+func (e *MarketEndpoint) GetCharacterOrderHistory(ctx context.Context, cid int, opt *ListOptions) (CharacterOrdersResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_orders_history", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return e.getCharacterOrders(ctx, u)
+}
+
+func (e *MarketEndpoint) getCharacterOrders(ctx context.Context, u string) (CharacterOrdersResponse, *Response, error) {
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var characterOrdersResponse CharacterOrdersResponse
+	resp, err := e.api.Do(ctx, req, &characterOrdersResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return characterOrdersResponse, resp, nil
+}
+
+// CorporationOrder holds a market order placed on behalf of a corporation.
+type CorporationOrder struct {
+	Duration       *int       `json:"duration,omitempty"`
+	Escrow         *float64   `json:"escrow,omitempty"`
+	IsBuyOrder     *bool      `json:"is_buy_order,omitempty"`
+	Issued         *Timestamp `json:"issued,omitempty"`
+	IssuedBy       *int       `json:"issued_by,omitempty"`
+	LocationID     *int64     `json:"location_id,omitempty"`
+	MinVolume      *int       `json:"min_volume,omitempty"`
+	OrderID        *int64     `json:"order_id,omitempty"`
+	Price          *float64   `json:"price,omitempty"`
+	Range          *string    `json:"range,omitempty"`
+	RegionID       *int       `json:"region_id,omitempty"`
+	State          *string    `json:"state,omitempty"`
+	TypeID         *int       `json:"type_id,omitempty"`
+	VolumeRemain   *int       `json:"volume_remain,omitempty"`
+	VolumeTotal    *int       `json:"volume_total,omitempty"`
+	WalletDivision *int       `json:"wallet_division,omitempty"`
+}
+
+func (s CorporationOrder) String() string {
+	return Stringify(s)
+}
+
+// CorporationOrdersResponse holds a list of corporation market orders.
+type CorporationOrdersResponse []*CorporationOrder
+
+// GetCorporationOrders returns a page of the open market orders of a
+// corporation.
+func (e *MarketEndpoint) GetCorporationOrders(ctx context.Context, cid int, opt *ListOptions) (CorporationOrdersResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_orders", cid)
+	return e.getCorporationOrders(ctx, u, opt)
+}
+
+// GetCorporationOrderHistory returns a page of the expired and cancelled
+// market orders of a corporation.
+func (e *MarketEndpoint) GetCorporationOrderHistory(ctx context.Context, cid int, opt *ListOptions) (CorporationOrdersResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_orders_history", cid)
+	return e.getCorporationOrders(ctx, u, opt)
+}
+
+func (e *MarketEndpoint) getCorporationOrders(ctx context.Context, u string, opt *ListOptions) (CorporationOrdersResponse, *Response, error) {
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var corporationOrdersResponse CorporationOrdersResponse
+	resp, err := e.api.Do(ctx, req, &corporationOrdersResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return corporationOrdersResponse, resp, nil
+}
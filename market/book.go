@@ -0,0 +1,293 @@
+package market
+
+import (
+	"sort"
+
+	"corpus.space/esi"
+)
+
+// A JumpFunc returns the number of jumps on the shortest route between two
+// solar systems. It returns false if there is no route.
+type JumpFunc func(from, to int) (int, bool)
+
+// Options configures how orders are grouped into books.
+type Options struct {
+	// Jumps is used to decide whether a buy order with a jump range reaches a
+	// location. If nil, jump ranges only cover the solar system of the order.
+	Jumps JumpFunc
+}
+
+// A Key identifies the book of a type at a location.
+type Key struct {
+	TypeID     int
+	LocationID int64
+}
+
+// Books groups the orders of a region into order books by type and location.
+type Books struct {
+	jumps JumpFunc
+
+	// orders by type
+	orders map[int][]Order
+
+	// solar system of each location with orders
+	systems map[int64]int
+
+	keys map[Key]bool
+}
+
+// NewBooks groups the given orders. The orders should all be from the same
+// region, as returned by esi.MarketEndpoint.ListAllRegionOrders.
+func NewBooks(orders esi.MarketOrdersResponse, opt *Options) *Books {
+	b := &Books{
+		orders:  make(map[int][]Order),
+		systems: make(map[int64]int),
+		keys:    make(map[Key]bool),
+	}
+
+	if opt != nil {
+		b.jumps = opt.Jumps
+	}
+
+	for _, o := range orders {
+		b.Add(FromESI(o))
+	}
+
+	return b
+}
+
+// Add adds an order to the books.
+func (b *Books) Add(o Order) {
+	b.orders[o.TypeID] = append(b.orders[o.TypeID], o)
+	b.keys[Key{o.TypeID, o.LocationID}] = true
+
+	if o.SystemID != 0 {
+		b.systems[o.LocationID] = o.SystemID
+	}
+}
+
+// Keys returns the type and location of every order, sorted by type and
+// location.
+func (b *Books) Keys() []Key {
+	keys := make([]Key, 0, len(b.keys))
+	for k := range b.keys {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].TypeID != keys[j].TypeID {
+			return keys[i].TypeID < keys[j].TypeID
+		}
+
+		return keys[i].LocationID < keys[j].LocationID
+	})
+
+	return keys
+}
+
+// Book returns the order book of a type at a location. The asks are the sell
+// orders at the location. The bids are the buy orders that can be filled from
+// the location; those placed at the location and those placed elsewhere with
+// a range that reaches it.
+func (b *Books) Book(typeID int, locationID int64) *Book {
+	book := &Book{TypeID: typeID, LocationID: locationID}
+	system := b.systems[locationID]
+
+	for _, o := range b.orders[typeID] {
+		if !o.IsBuy {
+			if o.LocationID == locationID {
+				book.Asks = append(book.Asks, o)
+			}
+
+			continue
+		}
+
+		if b.reaches(o, locationID, system) {
+			book.Bids = append(book.Bids, o)
+		}
+	}
+
+	book.sort()
+
+	return book
+}
+
+// RegionBook returns the order book of a type across the whole region,
+// ignoring locations and ranges.
+func (b *Books) RegionBook(typeID int) *Book {
+	book := &Book{TypeID: typeID}
+
+	for _, o := range b.orders[typeID] {
+		if o.IsBuy {
+			book.Bids = append(book.Bids, o)
+		} else {
+			book.Asks = append(book.Asks, o)
+		}
+	}
+
+	book.sort()
+
+	return book
+}
+
+// reaches reports whether the buy order o can be filled from the location in
+// the given solar system.
+func (b *Books) reaches(o Order, locationID int64, system int) bool {
+	if o.LocationID == locationID {
+		return true
+	}
+
+	switch o.Range {
+	case RangeStation:
+		return false
+	case RangeRegion:
+		return true
+	case RangeSolarSystem:
+		return system != 0 && o.SystemID == system
+	}
+
+	n, ok := o.Range.Jumps()
+	if !ok || system == 0 {
+		return false
+	}
+
+	if o.SystemID == system {
+		return true
+	}
+
+	if b.jumps == nil {
+		return false
+	}
+
+	d, ok := b.jumps(o.SystemID, system)
+
+	return ok && d <= n
+}
+
+// A Book is the order book of a type at a location.
+type Book struct {
+	TypeID     int
+	LocationID int64
+
+	// Bids holds the buy orders, highest price first.
+	Bids []Order
+
+	// Asks holds the sell orders, lowest price first.
+	Asks []Order
+}
+
+func (b *Book) sort() {
+	sort.SliceStable(b.Bids, func(i, j int) bool { return b.Bids[i].Price > b.Bids[j].Price })
+	sort.SliceStable(b.Asks, func(i, j int) bool { return b.Asks[i].Price < b.Asks[j].Price })
+}
+
+// BestBid returns the buy order with the highest price.
+func (b *Book) BestBid() (Order, bool) {
+	if len(b.Bids) == 0 {
+		return Order{}, false
+	}
+
+	return b.Bids[0], true
+}
+
+// BestAsk returns the sell order with the lowest price.
+func (b *Book) BestAsk() (Order, bool) {
+	if len(b.Asks) == 0 {
+		return Order{}, false
+	}
+
+	return b.Asks[0], true
+}
+
+// Spread returns the difference between the best ask and the best bid. It
+// returns false if either side of the book is empty.
+func (b *Book) Spread() (float64, bool) {
+	bid, ok := b.BestBid()
+	if !ok {
+		return 0, false
+	}
+
+	ask, ok := b.BestAsk()
+	if !ok {
+		return 0, false
+	}
+
+	return ask.Price - bid.Price, true
+}
+
+// Depth returns the volume of the buy orders priced within pct percent below
+// the best bid and the volume of the sell orders priced within pct percent
+// above the best ask.
+func (b *Book) Depth(pct float64) (bids, asks int64) {
+	if bid, ok := b.BestBid(); ok {
+		limit := bid.Price * (1 - pct/100)
+		for _, o := range b.Bids {
+			if o.Price < limit {
+				break
+			}
+
+			bids += int64(o.Volume)
+		}
+	}
+
+	if ask, ok := b.BestAsk(); ok {
+		limit := ask.Price * (1 + pct/100)
+		for _, o := range b.Asks {
+			if o.Price > limit {
+				break
+			}
+
+			asks += int64(o.Volume)
+		}
+	}
+
+	return bids, asks
+}
+
+// BuyPrice returns the volume weighted average price of buying quantity
+// units from the sell orders. It returns false if there is not enough volume.
+func (b *Book) BuyPrice(quantity int) (float64, bool) {
+	return vwap(b.Asks, quantity)
+}
+
+// SellPrice returns the volume weighted average price of selling quantity
+// units to the buy orders. Buy orders with a minimum volume larger than what
+// is left to sell are skipped. It returns false if there is not enough
+// volume.
+func (b *Book) SellPrice(quantity int) (float64, bool) {
+	return vwap(b.Bids, quantity)
+}
+
+// vwap fills quantity from orders in order and returns the average price.
+func vwap(orders []Order, quantity int) (float64, bool) {
+	if quantity <= 0 {
+		return 0, false
+	}
+
+	var total float64
+	left := quantity
+
+	for _, o := range orders {
+		if left == 0 {
+			break
+		}
+
+		n := o.Volume
+		if n > left {
+			n = left
+		}
+
+		if n < o.MinVolume {
+			continue
+		}
+
+		total += float64(n) * o.Price
+		left -= n
+	}
+
+	if left > 0 {
+		return 0, false
+	}
+
+	return total / float64(quantity), true
+}
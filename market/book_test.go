@@ -0,0 +1,227 @@
+package market
+
+import (
+	"reflect"
+	"testing"
+
+	"corpus.space/esi"
+)
+
+const (
+	jita44  = 60003760 // Jita IV - Moon 4 - Caldari Navy Assembly Plant
+	jita46  = 60003766 // Jita IV - Moon 6 - Caldari Business Tribunal
+	perimtr = 60003916 // Perimeter, one jump from Jita
+	niyabai = 60003940 // Niyabainen, two jumps from Jita
+
+	jita      = 30000142
+	perimeter = 30000144
+	niyabain  = 30000143
+
+	tritanium = 34
+)
+
+// testJumps is a jump table for the systems above.
+func testJumps(from, to int) (int, bool) {
+	dist := map[[2]int]int{
+		{jita, perimeter}:     1,
+		{jita, niyabain}:      2,
+		{perimeter, niyabain}: 1,
+	}
+
+	if from == to {
+		return 0, true
+	}
+
+	if d, ok := dist[[2]int{from, to}]; ok {
+		return d, true
+	}
+
+	d, ok := dist[[2]int{to, from}]
+
+	return d, ok
+}
+
+func order(id int64, buy bool, location int64, system int, price float64, volume int, r Range) Order {
+	return Order{
+		OrderID:    id,
+		TypeID:     tritanium,
+		LocationID: location,
+		SystemID:   system,
+		IsBuy:      buy,
+		Price:      price,
+		Volume:     volume,
+		Range:      r,
+	}
+}
+
+func testBooks(opt *Options) *Books {
+	b := NewBooks(nil, opt)
+
+	for _, o := range []Order{
+		order(1, false, jita44, jita, 5.10, 1000, RangeRegion),
+		order(2, false, jita44, jita, 5.00, 500, RangeRegion),
+		order(3, false, jita44, jita, 5.50, 2000, RangeRegion),
+		order(4, false, perimtr, perimeter, 4.90, 100, RangeRegion),
+
+		order(10, true, jita44, jita, 4.80, 1000, RangeStation),
+		order(11, true, jita46, jita, 4.85, 300, RangeSolarSystem),
+		order(12, true, jita46, jita, 4.95, 200, RangeStation),
+		order(13, true, perimtr, perimeter, 4.90, 400, "1"),
+		order(14, true, niyabai, niyabain, 4.99, 50, "1"),
+		order(15, true, niyabai, niyabain, 4.50, 5000, RangeRegion),
+	} {
+		b.Add(o)
+	}
+
+	return b
+}
+
+func orderIDs(orders []Order) []int64 {
+	var ids []int64
+	for _, o := range orders {
+		ids = append(ids, o.OrderID)
+	}
+
+	return ids
+}
+
+func TestBooks_Book(t *testing.T) {
+	book := testBooks(&Options{Jumps: testJumps}).Book(tritanium, jita44)
+
+	if want := []int64{2, 1, 3}; !reflect.DeepEqual(orderIDs(book.Asks), want) {
+		t.Errorf("Asks = %v, want %v", orderIDs(book.Asks), want)
+	}
+
+	// 12 is station range at another station and 14 is two jumps away
+	if want := []int64{13, 11, 10, 15}; !reflect.DeepEqual(orderIDs(book.Bids), want) {
+		t.Errorf("Bids = %v, want %v", orderIDs(book.Bids), want)
+	}
+}
+
+func TestBooks_Book_noJumps(t *testing.T) {
+	book := testBooks(nil).Book(tritanium, jita44)
+
+	// without a jump table, jump ranges only cover their own system
+	if want := []int64{11, 10, 15}; !reflect.DeepEqual(orderIDs(book.Bids), want) {
+		t.Errorf("Bids = %v, want %v", orderIDs(book.Bids), want)
+	}
+}
+
+func TestBooks_RegionBook(t *testing.T) {
+	book := testBooks(nil).RegionBook(tritanium)
+
+	if want := []int64{4, 2, 1, 3}; !reflect.DeepEqual(orderIDs(book.Asks), want) {
+		t.Errorf("Asks = %v, want %v", orderIDs(book.Asks), want)
+	}
+
+	if want := []int64{14, 12, 13, 11, 10, 15}; !reflect.DeepEqual(orderIDs(book.Bids), want) {
+		t.Errorf("Bids = %v, want %v", orderIDs(book.Bids), want)
+	}
+}
+
+func TestBooks_Keys(t *testing.T) {
+	keys := testBooks(nil).Keys()
+
+	want := []Key{
+		{tritanium, jita44},
+		{tritanium, jita46},
+		{tritanium, perimtr},
+		{tritanium, niyabai},
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("Keys() = %v, want %v", keys, want)
+	}
+}
+
+func TestNewBooks(t *testing.T) {
+	b := NewBooks(esi.MarketOrdersResponse{
+		{OrderID: esi.Int64(1), TypeID: esi.Int(tritanium), LocationID: esi.Int64(jita44), IsBuyOrder: esi.Bool(false), Price: esi.Float64(5)},
+	}, nil)
+
+	if ask, ok := b.Book(tritanium, jita44).BestAsk(); !ok || ask.OrderID != 1 {
+		t.Errorf("BestAsk() = %+v, %v", ask, ok)
+	}
+}
+
+func TestBook_prices(t *testing.T) {
+	book := testBooks(&Options{Jumps: testJumps}).Book(tritanium, jita44)
+
+	if bid, ok := book.BestBid(); !ok || bid.Price != 4.90 {
+		t.Errorf("BestBid() = %+v, %v", bid, ok)
+	}
+
+	if ask, ok := book.BestAsk(); !ok || ask.Price != 5.00 {
+		t.Errorf("BestAsk() = %+v, %v", ask, ok)
+	}
+
+	if spread, ok := book.Spread(); !ok || spread < 0.0999 || spread > 0.1001 {
+		t.Errorf("Spread() = %v, %v; want 0.1", spread, ok)
+	}
+}
+
+func TestBook_empty(t *testing.T) {
+	book := testBooks(nil).Book(tritanium+1, jita44)
+
+	if _, ok := book.BestBid(); ok {
+		t.Errorf("BestBid() returned an order")
+	}
+
+	if _, ok := book.BestAsk(); ok {
+		t.Errorf("BestAsk() returned an order")
+	}
+
+	if _, ok := book.Spread(); ok {
+		t.Errorf("Spread() returned a spread")
+	}
+
+	if bids, asks := book.Depth(5); bids != 0 || asks != 0 {
+		t.Errorf("Depth(5) = %d, %d; want 0, 0", bids, asks)
+	}
+}
+
+func TestBook_Depth(t *testing.T) {
+	book := testBooks(&Options{Jumps: testJumps}).Book(tritanium, jita44)
+
+	// bids down to 4.655; asks up to 5.25
+	bids, asks := book.Depth(5)
+	if bids != 1700 || asks != 1500 {
+		t.Errorf("Depth(5) = %d, %d; want 1700, 1500", bids, asks)
+	}
+}
+
+func TestBook_BuyPrice(t *testing.T) {
+	book := testBooks(nil).Book(tritanium, jita44)
+
+	// 500 at 5.00 and 500 at 5.10
+	if price, ok := book.BuyPrice(1000); !ok || price != 5.05 {
+		t.Errorf("BuyPrice(1000) = %v, %v; want 5.05", price, ok)
+	}
+
+	if _, ok := book.BuyPrice(4000); ok {
+		t.Errorf("BuyPrice(4000) succeeded with only 3500 available")
+	}
+
+	if _, ok := book.BuyPrice(0); ok {
+		t.Errorf("BuyPrice(0) succeeded")
+	}
+}
+
+func TestBook_SellPrice_minVolume(t *testing.T) {
+	b := NewBooks(nil, nil)
+
+	high := order(1, true, jita44, jita, 6, 1000, RangeStation)
+	high.MinVolume = 500
+	b.Add(high)
+	b.Add(order(2, true, jita44, jita, 5, 1000, RangeStation))
+
+	book := b.Book(tritanium, jita44)
+
+	if price, ok := book.SellPrice(600); !ok || price != 6 {
+		t.Errorf("SellPrice(600) = %v, %v; want 6", price, ok)
+	}
+
+	// too little to fill the minimum volume of the best bid
+	if price, ok := book.SellPrice(100); !ok || price != 5 {
+		t.Errorf("SellPrice(100) = %v, %v; want 5", price, ok)
+	}
+}
@@ -0,0 +1,118 @@
+// Package market aggregates ESI market orders into order books.
+//
+// Orders are usually fetched with esi.MarketEndpoint.ListAllRegionOrders and
+// grouped into a book per type and location:
+//
+//	orders, err := client.Market.ListAllRegionOrders(ctx, regionID, nil)
+//	if err != nil {
+//		...
+//	}
+//
+//	books := market.NewBooks(orders, nil)
+//	book := books.Book(typeID, stationID)
+//
+//	bid, _ := book.BestBid()
+//	ask, _ := book.BestAsk()
+package market
+
+import (
+	"strconv"
+	"time"
+
+	"corpus.space/esi"
+)
+
+// Range is the range of a market order. Sell orders always have RangeRegion.
+// Buy orders can also be filled from other stations within their range. Jump
+// ranges are given as a number, such as "5".
+type Range string
+
+// Order ranges.
+const (
+	RangeStation     Range = "station"
+	RangeSolarSystem Range = "solarsystem"
+	RangeRegion      Range = "region"
+)
+
+// Jumps returns the number of jumps of a jump range. It returns false if r is
+// not a jump range.
+func (r Range) Jumps() (int, bool) {
+	n, err := strconv.Atoi(string(r))
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// An Order is a market order.
+type Order struct {
+	OrderID    int64
+	TypeID     int
+	LocationID int64
+	SystemID   int
+	IsBuy      bool
+	Price      float64
+	Volume     int
+	MinVolume  int
+	Range      Range
+	Issued     time.Time
+	Duration   int
+}
+
+// FromESI converts an order as returned by ESI. Missing fields are left at
+// their zero values.
+func FromESI(o *esi.MarketOrder) Order {
+	var order Order
+
+	if o.OrderID != nil {
+		order.OrderID = *o.OrderID
+	}
+
+	if o.TypeID != nil {
+		order.TypeID = *o.TypeID
+	}
+
+	if o.LocationID != nil {
+		order.LocationID = *o.LocationID
+	}
+
+	if o.SystemID != nil {
+		order.SystemID = *o.SystemID
+	}
+
+	if o.IsBuyOrder != nil {
+		order.IsBuy = *o.IsBuyOrder
+	}
+
+	if o.Price != nil {
+		order.Price = *o.Price
+	}
+
+	if o.VolumeRemain != nil {
+		order.Volume = *o.VolumeRemain
+	}
+
+	if o.MinVolume != nil {
+		order.MinVolume = *o.MinVolume
+	}
+
+	if o.Range != nil {
+		order.Range = Range(*o.Range)
+	}
+
+	if o.Issued != nil {
+		order.Issued = o.Issued.Time
+	}
+
+	if o.Duration != nil {
+		order.Duration = *o.Duration
+	}
+
+	return order
+}
+
+// Expires returns the time the order expires.
+func (o Order) Expires() time.Time {
+	return o.Issued.AddDate(0, 0, o.Duration)
+}
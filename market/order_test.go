@@ -0,0 +1,81 @@
+package market
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"corpus.space/esi"
+)
+
+func TestRange_Jumps(t *testing.T) {
+	for _, tt := range []struct {
+		r     Range
+		jumps int
+		ok    bool
+	}{
+		{RangeStation, 0, false},
+		{RangeSolarSystem, 0, false},
+		{RangeRegion, 0, false},
+		{"1", 1, true},
+		{"40", 40, true},
+		{"-1", 0, false},
+	} {
+		jumps, ok := tt.r.Jumps()
+		if jumps != tt.jumps || ok != tt.ok {
+			t.Errorf("Range(%q).Jumps() = %d, %v; want %d, %v", tt.r, jumps, ok, tt.jumps, tt.ok)
+		}
+	}
+}
+
+func TestFromESI(t *testing.T) {
+	var o esi.MarketOrder
+	err := json.Unmarshal([]byte(`
+		{
+			"duration": 90,
+			"is_buy_order": true,
+			"issued": "2016-09-03T05:12:25Z",
+			"location_id": 60005599,
+			"min_volume": 10,
+			"order_id": 4623824223,
+			"price": 9.9,
+			"range": "5",
+			"system_id": 30000053,
+			"type_id": 34,
+			"volume_remain": 1296000,
+			"volume_total": 2000000
+		}
+	`), &o)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := FromESI(&o)
+	want := Order{
+		OrderID:    4623824223,
+		TypeID:     34,
+		LocationID: 60005599,
+		SystemID:   30000053,
+		IsBuy:      true,
+		Price:      9.9,
+		Volume:     1296000,
+		MinVolume:  10,
+		Range:      "5",
+		Issued:     time.Date(2016, 9, 3, 5, 12, 25, 0, time.UTC),
+		Duration:   90,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromESI returned %+v, want %+v", got, want)
+	}
+
+	if want := time.Date(2016, 12, 2, 5, 12, 25, 0, time.UTC); !got.Expires().Equal(want) {
+		t.Errorf("Expires() = %v, want %v", got.Expires(), want)
+	}
+}
+
+func TestFromESI_empty(t *testing.T) {
+	if got := FromESI(&esi.MarketOrder{}); !reflect.DeepEqual(got, Order{}) {
+		t.Errorf("FromESI returned %+v, want zero Order", got)
+	}
+}
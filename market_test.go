@@ -0,0 +1,288 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMarketEndpoint_GetRegionOrders(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/markets/10000002/orders/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"order_type": "sell", "type_id": "34", "page": "2"})
+		fmt.Fprint(w, `
+			[
+				{
+					"duration": 90,
+					"is_buy_order": false,
+					"issued": "2016-09-03T05:12:25Z",
+					"location_id": 60005599,
+					"min_volume": 1,
+					"order_id": 4623824223,
+					"price": 9.9,
+					"range": "region",
+					"system_id": 30000053,
+					"type_id": 34,
+					"volume_remain": 1296000,
+					"volume_total": 2000000
+				}
+			]
+		`)
+	})
+
+	opt := &RegionOrdersOptions{ListOptions: ListOptions{Page: 2}, OrderType: OrderTypeSell, TypeID: 34}
+	orders, _, err := client.Market.GetRegionOrders(context.Background(), 10000002, opt)
+	if err != nil {
+		t.Errorf("Market.GetRegionOrders returned error: %v", err)
+	}
+
+	want := MarketOrdersResponse{
+		{
+			Duration:     Int(90),
+			IsBuyOrder:   Bool(false),
+			Issued:       &Timestamp{time.Date(2016, 9, 3, 5, 12, 25, 0, time.UTC)},
+			LocationID:   Int64(60005599),
+			MinVolume:    Int(1),
+			OrderID:      Int64(4623824223),
+			Price:        Float64(9.9),
+			Range:        String("region"),
+			SystemID:     Int(30000053),
+			TypeID:       Int(34),
+			VolumeRemain: Int(1296000),
+			VolumeTotal:  Int(2000000),
+		},
+	}
+	if !reflect.DeepEqual(orders, want) {
+		t.Errorf("Market.GetRegionOrders returned %+v, want %+v", orders, want)
+	}
+}
+
+func TestMarketEndpoint_GetRegionOrders_defaultOrderType(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/markets/10000002/orders/", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"order_type": "all"})
+		fmt.Fprint(w, `[]`)
+	})
+
+	if _, _, err := client.Market.GetRegionOrders(context.Background(), 10000002, nil); err != nil {
+		t.Errorf("Market.GetRegionOrders returned error: %v", err)
+	}
+}
+
+func TestMarketEndpoint_ListAllRegionOrders(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.MaxConcurrentPages = 2
+
+	mux.HandleFunc("/v1/markets/10000002/orders/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.FormValue("order_type"); got != "buy" {
+			t.Errorf("order_type: %q, want %q", got, "buy")
+		}
+
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		w.Header().Set("X-Pages", "3")
+		fmt.Fprintf(w, `[{"order_id": %d}, {"order_id": %d}]`, page*10, page*10+1)
+	})
+
+	orders, err := client.Market.ListAllRegionOrders(context.Background(), 10000002, &RegionOrdersOptions{OrderType: OrderTypeBuy})
+	if err != nil {
+		t.Fatalf("Market.ListAllRegionOrders returned error: %v", err)
+	}
+
+	var got []int64
+	for _, o := range orders {
+		got = append(got, *o.OrderID)
+	}
+
+	want := []int64{10, 11, 20, 21, 30, 31}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Market.ListAllRegionOrders returned orders %v, want %v", got, want)
+	}
+}
+
+func TestMarketEndpoint_ListAllRegionOrders_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/markets/10000002/orders/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Pages", "3")
+		if r.FormValue("page") == "2" {
+			http.Error(w, `{"error": "not found"}`, http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(w, `[{}]`)
+	})
+
+	if _, err := client.Market.ListAllRegionOrders(context.Background(), 10000002, nil); err == nil {
+		t.Errorf("Market.ListAllRegionOrders returned no error")
+	}
+}
+
+func TestMarketEndpoint_GetRegionHistory(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/markets/10000002/history/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"type_id": "34"})
+		fmt.Fprint(w, `
+			[
+				{
+					"average": 5.25,
+					"date": "2015-05-01",
+					"highest": 5.27,
+					"lowest": 5.11,
+					"order_count": 2267,
+					"volume": 16276782035
+				}
+			]
+		`)
+	})
+
+	history, _, err := client.Market.GetRegionHistory(context.Background(), 10000002, 34)
+	if err != nil {
+		t.Errorf("Market.GetRegionHistory returned error: %v", err)
+	}
+
+	want := MarketHistoryResponse{
+		{
+			Average:    Float64(5.25),
+			Date:       &Date{time.Date(2015, 5, 1, 0, 0, 0, 0, time.UTC)},
+			Highest:    Float64(5.27),
+			Lowest:     Float64(5.11),
+			OrderCount: Int64(2267),
+			Volume:     Int64(16276782035),
+		},
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("Market.GetRegionHistory returned %+v, want %+v", history, want)
+	}
+}
+
+func TestMarketEndpoint_GetGroup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/markets/groups/5/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"language": "en-us"})
+		fmt.Fprint(w, `
+			{
+				"description": "Small, fast vessels suited to a variety of purposes.",
+				"market_group_id": 5,
+				"name": "Standard Frigates",
+				"parent_group_id": 1361,
+				"types": [582, 583]
+			}
+		`)
+	})
+
+	group, _, err := client.Market.GetGroup(context.Background(), 5, &I18NOptions{Language: "en-us"})
+	if err != nil {
+		t.Errorf("Market.GetGroup returned error: %v", err)
+	}
+
+	want := &MarketGroup{
+		Description:   String("Small, fast vessels suited to a variety of purposes."),
+		MarketGroupID: Int(5),
+		Name:          String("Standard Frigates"),
+		ParentGroupID: Int(1361),
+		Types:         []int{582, 583},
+	}
+	if !reflect.DeepEqual(group, want) {
+		t.Errorf("Market.GetGroup returned %+v, want %+v", group, want)
+	}
+}
+
+func TestMarketEndpoint_GetPrices(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/markets/prices/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"adjusted_price": 306988.09, "average_price": 306292.67, "type_id": 32772}]`)
+	})
+
+	prices, _, err := client.Market.GetPrices(context.Background())
+	if err != nil {
+		t.Errorf("Market.GetPrices returned error: %v", err)
+	}
+
+	want := MarketPricesResponse{
+		{AdjustedPrice: Float64(306988.09), AveragePrice: Float64(306292.67), TypeID: Int(32772)},
+	}
+	if !reflect.DeepEqual(prices, want) {
+		t.Errorf("Market.GetPrices returned %+v, want %+v", prices, want)
+	}
+}
+
+func TestMarketEndpoint_lists(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	for _, path := range []string{
+		"/v1/markets/groups/",
+		"/v1/markets/10000002/types/",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `[1]`)
+		})
+	}
+
+	for _, path := range []string{
+		"/v2/characters/42/orders/",
+		"/v1/characters/42/orders/history/",
+		"/v3/corporations/42/orders/",
+		"/v2/corporations/42/orders/history/",
+		"/v1/markets/structures/1021975535893/",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `[{}]`)
+		})
+	}
+
+	ctx := context.Background()
+	opt := &ListOptions{Page: 1}
+
+	if v, _, err := client.Market.ListGroups(ctx); err != nil || len(v) != 1 {
+		t.Errorf("Market.ListGroups returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Market.GetRegionTypes(ctx, 10000002, opt); err != nil || len(v) != 1 {
+		t.Errorf("Market.GetRegionTypes returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Market.GetCharacterOrders(ctx, 42); err != nil || len(v) != 1 {
+		t.Errorf("Market.GetCharacterOrders returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Market.GetCharacterOrderHistory(ctx, 42, opt); err != nil || len(v) != 1 {
+		t.Errorf("Market.GetCharacterOrderHistory returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Market.GetCorporationOrders(ctx, 42, opt); err != nil || len(v) != 1 {
+		t.Errorf("Market.GetCorporationOrders returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Market.GetCorporationOrderHistory(ctx, 42, opt); err != nil || len(v) != 1 {
+		t.Errorf("Market.GetCorporationOrderHistory returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Market.GetStructureOrders(ctx, 1021975535893, opt); err != nil || len(v) != 1 {
+		t.Errorf("Market.GetStructureOrders returned %v, %v", v, err)
+	}
+}
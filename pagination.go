@@ -0,0 +1,244 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+const (
+	// DefaultMaxConcurrentPages is the default number of pages fetched
+	// concurrently by a PageIterator.
+	DefaultMaxConcurrentPages = 4
+
+	headerPages = "X-Pages"
+)
+
+// ListOptions specifies the optional page parameter to methods that support
+// pagination. It is intended to be embedded in option structs.
+type ListOptions struct {
+	// The page to fetch. ESI pages are numbered from 1; a zero value fetches
+	// the first page.
+	Page int `url:"page,omitempty"`
+}
+
+// parsePages sets the Pages and Page fields of the response from the X-Pages
+// header and the page query parameter of the request.
+func (r *Response) parsePages() {
+	if v := r.Header.Get(headerPages); v != "" {
+		r.Pages, _ = strconv.Atoi(v)
+	}
+
+	r.Page = 1
+	if r.Request != nil && r.Request.URL != nil {
+		if v, err := strconv.Atoi(r.Request.URL.Query().Get("page")); err == nil && v > 0 {
+			r.Page = v
+		}
+	}
+}
+
+// A PageFunc fetches a single page of a paginated route. It must return the
+// items on the page as a slice.
+type PageFunc func(ctx context.Context, page int) (interface{}, *Response, error)
+
+type pageResult struct {
+	items interface{}
+	err   error
+}
+
+// A PageIterator iterates over the items of all pages of a paginated route.
+// The first page is fetched synchronously to learn the number of pages from
+// the X-Pages header. The remaining pages are then fetched concurrently, but
+// items are always yielded in page order.
+//
+// Iteration stops at the first error or if the context is canceled. Callers
+// should always call Close when done with the iterator:
+//
+//	it := client.NewPageIterator(ctx, fetch)
+//	defer it.Close()
+//
+//	for it.Next() {
+//		item := it.Item().(*SomeType)
+//		...
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type PageIterator struct {
+	api   *Client
+	fetch PageFunc
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	started bool
+	pages   int
+	page    int
+	results []chan pageResult
+
+	items reflect.Value
+	idx   int
+	item  interface{}
+	err   error
+}
+
+// NewPageIterator returns an iterator over all items returned by fetch. At
+// most MaxConcurrentPages pages are fetched concurrently.
+func (api *Client) NewPageIterator(ctx context.Context, fetch PageFunc) *PageIterator {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &PageIterator{
+		api:    api,
+		fetch:  fetch,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Next advances the iterator to the next item, which will then be available
+// through the Item method. It returns false when iteration stops, either by
+// reaching the end of the last page or an error.
+func (it *PageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if !it.started {
+		it.started = true
+
+		if err := it.start(); err != nil {
+			it.stop(err)
+			return false
+		}
+	}
+
+	for !it.items.IsValid() || it.idx >= it.items.Len() {
+		if it.page >= it.pages {
+			it.cancel()
+			return false
+		}
+
+		if err := it.ctx.Err(); err != nil {
+			it.stop(err)
+			return false
+		}
+
+		it.page++
+
+		var res pageResult
+		select {
+		case res = <-it.results[it.page-2]:
+		case <-it.ctx.Done():
+			res.err = it.ctx.Err()
+		}
+
+		if res.err != nil {
+			it.stop(res.err)
+			return false
+		}
+
+		if err := it.setItems(res.items); err != nil {
+			it.stop(err)
+			return false
+		}
+	}
+
+	it.item = it.items.Index(it.idx).Interface()
+	it.idx++
+
+	return true
+}
+
+// Item returns the current item.
+func (it *PageIterator) Item() interface{} {
+	return it.item
+}
+
+// Pages returns the number of pages as reported by ESI on the first page. It
+// returns zero until the first call to Next.
+func (it *PageIterator) Pages() int {
+	return it.pages
+}
+
+// Err returns the first error encountered during iteration, if any.
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iterator and cancels any outstanding page requests.
+func (it *PageIterator) Close() {
+	it.cancel()
+}
+
+func (it *PageIterator) stop(err error) {
+	it.err = err
+	it.cancel()
+}
+
+func (it *PageIterator) start() error {
+	items, resp, err := it.fetch(it.ctx, 1)
+	if err != nil {
+		return err
+	}
+
+	if err := it.setItems(items); err != nil {
+		return err
+	}
+
+	it.page = 1
+	it.pages = 1
+	if resp != nil && resp.Pages > 1 {
+		it.pages = resp.Pages
+	}
+
+	it.results = make([]chan pageResult, it.pages-1)
+	for i := range it.results {
+		it.results[i] = make(chan pageResult, 1)
+	}
+
+	n := it.api.MaxConcurrentPages
+	if n <= 0 {
+		n = DefaultMaxConcurrentPages
+	}
+
+	go func() {
+		sem := make(chan struct{}, n)
+
+		for page := 2; page <= it.pages; page++ {
+			select {
+			case sem <- struct{}{}:
+			case <-it.ctx.Done():
+				return
+			}
+
+			go func(page int) {
+				defer func() { <-sem }()
+
+				items, _, err := it.fetch(it.ctx, page)
+				it.results[page-2] <- pageResult{items, err}
+			}(page)
+		}
+	}()
+
+	return nil
+}
+
+func (it *PageIterator) setItems(items interface{}) error {
+	it.idx = 0
+
+	if items == nil {
+		it.items = reflect.ValueOf([]interface{}{})
+		return nil
+	}
+
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("esi: page items must be a slice; got %T", items)
+	}
+
+	it.items = v
+
+	return nil
+}
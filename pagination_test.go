@@ -0,0 +1,209 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func listInts(client *Client) PageFunc {
+	return func(ctx context.Context, page int) (interface{}, *Response, error) {
+		u, err := addOptions(".", &ListOptions{Page: page})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		req, err := client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var items []int
+		resp, err := client.Do(ctx, req, &items)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		return items, resp, nil
+	}
+}
+
+func TestResponse_pages(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"page": "2"})
+		w.Header().Set("X-Pages", "3")
+	})
+
+	u, _ := addOptions(".", &ListOptions{Page: 2})
+	req, _ := client.NewRequest("GET", u, nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Pages != 3 {
+		t.Errorf("unexpected Pages; got %d, want 3", resp.Pages)
+	}
+
+	if resp.Page != 2 {
+		t.Errorf("unexpected Page; got %d, want 2", resp.Page)
+	}
+}
+
+func TestAddOptions_embeddedListOptions(t *testing.T) {
+	opt := struct {
+		ListOptions
+		TypeID int `url:"type_id,omitempty"`
+	}{ListOptions{Page: 2}, 34}
+
+	if s, _ := addOptions(".", opt); s != ".?page=2&type_id=34" {
+		t.Fatalf("want \".?page=2&type_id=34\"; got %q", s)
+	}
+}
+
+func TestPageIterator(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.MaxConcurrentPages = 2
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		w.Header().Set("X-Pages", "4")
+		fmt.Fprintf(w, "[%d, %d]", page*10, page*10+1)
+	})
+
+	it := client.NewPageIterator(context.Background(), listInts(client))
+	defer it.Close()
+
+	var got []int
+	for it.Next() {
+		got = append(got, it.Item().(int))
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if it.Pages() != 4 {
+		t.Errorf("unexpected Pages(); got %d, want 4", it.Pages())
+	}
+
+	want := []int{10, 11, 20, 21, 30, 31, 40, 41}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PageIterator yielded %v, want %v", got, want)
+	}
+}
+
+func TestPageIterator_singlePage(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "[1, 2, 3]")
+	})
+
+	it := client.NewPageIterator(context.Background(), listInts(client))
+	defer it.Close()
+
+	var n int
+	for it.Next() {
+		n++
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n != 3 {
+		t.Errorf("expected 3 items; got %d", n)
+	}
+}
+
+func TestPageIterator_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Pages", "3")
+
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, `{"error":"some error"}`, 500)
+			return
+		}
+
+		fmt.Fprint(w, "[1]")
+	})
+
+	it := client.NewPageIterator(context.Background(), listInts(client))
+	defer it.Close()
+
+	var n int
+	for it.Next() {
+		n++
+	}
+
+	if n != 1 {
+		t.Errorf("expected 1 item before error; got %d", n)
+	}
+
+	if _, ok := it.Err().(*Error); !ok {
+		t.Fatalf("expected *Error; got %v", it.Err())
+	}
+
+	if it.Next() {
+		t.Fatal("expected Next to return false after error")
+	}
+}
+
+func TestPageIterator_canceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Pages", "3")
+		fmt.Fprint(w, "[1]")
+	})
+
+	it := client.NewPageIterator(ctx, listInts(client))
+	defer it.Close()
+
+	if !it.Next() {
+		t.Fatalf("expected first item; got error %v", it.Err())
+	}
+
+	cancel()
+
+	for it.Next() {
+	}
+
+	if it.Err() != context.Canceled {
+		t.Fatalf("expected context.Canceled; got %v", it.Err())
+	}
+}
+
+func TestPageIterator_notSlice(t *testing.T) {
+	client := NewClient(nil)
+
+	it := client.NewPageIterator(context.Background(), func(ctx context.Context, page int) (interface{}, *Response, error) {
+		return 42, nil, nil
+	})
+	defer it.Close()
+
+	if it.Next() {
+		t.Fatal("expected Next to return false")
+	}
+
+	if it.Err() == nil {
+		t.Fatal("expected error")
+	}
+}
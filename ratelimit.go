@@ -0,0 +1,59 @@
+package esi
+
+import "context"
+
+const (
+	// DefaultErrorLimitThreshold is the default value of the
+	// ErrorLimitThreshold field of a Client.
+	DefaultErrorLimitThreshold = 10
+
+	// StatusErrorLimited is the HTTP status code used by ESI when the error
+	// limit has been exceeded.
+	StatusErrorLimited = 420
+)
+
+// ErrorLimitedError is returned when ESI responds with HTTP 420, meaning the
+// error limit has been exceeded. No requests should be made until the error
+// limit window resets; continuing to make requests may get the client banned.
+type ErrorLimitedError struct {
+	Err *Error
+}
+
+func (e *ErrorLimitedError) Error() string {
+	return "esi: error limited: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying *Error.
+func (e *ErrorLimitedError) Unwrap() error {
+	return e.Err
+}
+
+// Rate returns the most recently received error rate limit information.
+func (api *Client) Rate() Rate {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	return api.mu.Rate
+}
+
+// waitErrorLimit blocks until the error limit window resets if the number of
+// remaining errors is below the configured threshold.
+func (api *Client) waitErrorLimit(ctx context.Context) error {
+	if api.ErrorLimitThreshold <= 0 {
+		return nil
+	}
+
+	rate := api.Rate()
+	if rate.Remaining >= api.ErrorLimitThreshold {
+		return nil
+	}
+
+	d := rate.Reset.Sub(now())
+	if d <= 0 {
+		return nil
+	}
+
+	logf(api.Logging.Info, "%v; pausing requests", rate)
+
+	return sleep(ctx, d)
+}
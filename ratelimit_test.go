@@ -0,0 +1,124 @@
+package esi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDo_rateUpdatedOnSuccess(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-ESI-Error-Limit-Remain", "87")
+		w.Header().Set("X-ESI-Error-Limit-Reset", "30")
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := client.Rate().Remaining; got != 87 {
+		t.Fatalf("unexpected Rate().Remaining; got %d, want 87", got)
+	}
+}
+
+func TestDo_errorLimited(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-ESI-Error-Limit-Remain", "0")
+		w.Header().Set("X-ESI-Error-Limit-Reset", "42")
+
+		http.Error(w, `{"error":"This software has exceeded the error limit for ESI."}`, StatusErrorLimited)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	_, err := client.Do(context.Background(), req, nil)
+
+	v, ok := err.(*ErrorLimitedError)
+	if !ok {
+		t.Fatalf("expected *ErrorLimitedError; got %T", err)
+	}
+
+	if v.Err.HTTPStatusCode != StatusErrorLimited {
+		t.Fatalf("unexpected status code; got %d", v.Err.HTTPStatusCode)
+	}
+
+	if rate := client.Rate(); rate.Remaining != 0 || rate.Reset.IsZero() {
+		t.Fatalf("unexpected rate; got %+v", rate)
+	}
+}
+
+func TestDo_errorLimitThrottle(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.ErrorLimitThreshold = 5
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	client.mu.Rate = Rate{Remaining: 2, Reset: time.Now().Add(50 * time.Millisecond)}
+
+	start := time.Now()
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("expected request to be paused until reset; took %v", elapsed)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected 1 call to the server; got %d", calls)
+	}
+}
+
+func TestDo_errorLimitThrottleCanceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.ErrorLimitThreshold = 5
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("unexpected request")
+	})
+
+	client.mu.Rate = Rate{Remaining: 2, Reset: time.Now().Add(time.Minute)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	if _, err := client.Do(ctx, req, nil); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded; got %v", err)
+	}
+}
+
+func TestDo_errorLimitThrottleDisabled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.ErrorLimitThreshold = 0
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+
+	client.mu.Rate = Rate{Remaining: 0, Reset: time.Now().Add(time.Minute)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
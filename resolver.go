@@ -0,0 +1,236 @@
+package esi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	// MaxNameIDs is the maximum number of IDs accepted by a single
+	// UniverseEndpoint.GetNames request.
+	MaxNameIDs = 1000
+
+	// MaxIDNames is the maximum number of names accepted by a single
+	// UniverseEndpoint.GetIDs request.
+	MaxIDNames = 500
+)
+
+// A Name is a resolved name.
+type Name struct {
+	ID       int
+	Name     string
+	Category NameCategory
+}
+
+// UnresolvedError is returned by NameResolver.Resolve if some of the IDs
+// could not be resolved.
+type UnresolvedError struct {
+	IDs []int
+}
+
+func (e *UnresolvedError) Error() string {
+	return fmt.Sprintf("esi: %d IDs could not be resolved: %v", len(e.IDs), e.IDs)
+}
+
+// A NameResolver resolves IDs to names in bulk. Inputs are deduplicated and
+// split into requests of the maximum size accepted by ESI. Since ESI fails a
+// whole request if any of the IDs is invalid, failing requests are split in
+// half until the invalid IDs are isolated.
+//
+// Results, including IDs found to be invalid, are cached for the lifetime of
+// the resolver. A NameResolver is safe for concurrent use.
+type NameResolver struct {
+	api *Client
+
+	mu      sync.Mutex
+	names   map[int]Name
+	invalid map[int]bool
+}
+
+// NewNameResolver returns a new NameResolver that makes requests with api.
+func NewNameResolver(api *Client) *NameResolver {
+	return &NameResolver{
+		api:     api,
+		names:   make(map[int]Name),
+		invalid: make(map[int]bool),
+	}
+}
+
+// Resolve returns the names of the given IDs. If some of the IDs are invalid,
+// the names of the others are returned along with an *UnresolvedError listing
+// the invalid IDs. Any other error aborts resolution.
+func (r *NameResolver) Resolve(ctx context.Context, ids []int) (map[int]Name, error) {
+	names := make(map[int]Name, len(ids))
+
+	var unresolved, pending []int
+	seen := make(map[int]bool, len(ids))
+
+	r.mu.Lock()
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+
+		seen[id] = true
+
+		if n, ok := r.names[id]; ok {
+			names[id] = n
+		} else if r.invalid[id] {
+			unresolved = append(unresolved, id)
+		} else {
+			pending = append(pending, id)
+		}
+	}
+	r.mu.Unlock()
+
+	for len(pending) > 0 {
+		n := len(pending)
+		if n > MaxNameIDs {
+			n = MaxNameIDs
+		}
+
+		bad, err := r.resolve(ctx, pending[:n], names)
+		if err != nil {
+			return nil, err
+		}
+
+		unresolved = append(unresolved, bad...)
+		pending = pending[n:]
+	}
+
+	if len(unresolved) > 0 {
+		sort.Ints(unresolved)
+		return names, &UnresolvedError{IDs: unresolved}
+	}
+
+	return names, nil
+}
+
+// resolve resolves a batch of IDs into names, bisecting the batch if ESI
+// rejects it. It returns the IDs found to be invalid.
+func (r *NameResolver) resolve(ctx context.Context, ids []int, names map[int]Name) ([]int, error) {
+	res, _, err := r.api.Universe.GetNames(ctx, ids)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrBadRequest) {
+			return nil, err
+		}
+
+		if len(ids) == 1 {
+			r.mu.Lock()
+			r.invalid[ids[0]] = true
+			r.mu.Unlock()
+
+			return ids, nil
+		}
+
+		mid := len(ids) / 2
+
+		bad, err := r.resolve(ctx, ids[:mid], names)
+		if err != nil {
+			return nil, err
+		}
+
+		more, err := r.resolve(ctx, ids[mid:], names)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(bad, more...), nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := make(map[int]bool, len(res))
+	for _, v := range res {
+		if v.ID == nil {
+			continue
+		}
+
+		n := Name{ID: *v.ID}
+		if v.Name != nil {
+			n.Name = *v.Name
+		}
+
+		if v.Category != nil {
+			n.Category = *v.Category
+		}
+
+		r.names[n.ID] = n
+		names[n.ID] = n
+		found[n.ID] = true
+	}
+
+	// IDs that were accepted but not returned
+	var bad []int
+	for _, id := range ids {
+		if !found[id] {
+			r.invalid[id] = true
+			bad = append(bad, id)
+		}
+	}
+
+	return bad, nil
+}
+
+// Lookup returns the IDs of the given exact names. A name can match IDs in
+// several categories; names that match nothing are not included in the
+// result. Agents are not included.
+func (r *NameResolver) Lookup(ctx context.Context, names []string) (map[string][]Name, error) {
+	ids := make(map[string][]Name)
+
+	var pending []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			pending = append(pending, name)
+		}
+	}
+
+	for len(pending) > 0 {
+		n := len(pending)
+		if n > MaxIDNames {
+			n = MaxIDNames
+		}
+
+		res, _, err := r.api.Universe.GetIDs(ctx, pending[:n], nil)
+		if err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		for _, c := range []struct {
+			category NameCategory
+			ids      []*UniverseID
+		}{
+			{CategoryAlliance, res.Alliances},
+			{CategoryCharacter, res.Characters},
+			{CategoryConstellation, res.Constellations},
+			{CategoryCorporation, res.Corporations},
+			{CategoryFaction, res.Factions},
+			{CategoryInventoryType, res.InventoryTypes},
+			{CategoryRegion, res.Regions},
+			{CategoryStation, res.Stations},
+			{CategorySolarSystem, res.Systems},
+		} {
+			for _, v := range c.ids {
+				if v.ID == nil || v.Name == nil {
+					continue
+				}
+
+				name := Name{ID: *v.ID, Name: *v.Name, Category: c.category}
+				ids[name.Name] = append(ids[name.Name], name)
+				r.names[name.ID] = name
+			}
+		}
+		r.mu.Unlock()
+
+		pending = pending[n:]
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,183 @@
+package esi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// namesHandler serves the names of IDs, failing requests with any of the bad
+// IDs like ESI does.
+func namesHandler(t *testing.T, bad map[int]bool, calls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		*calls++
+
+		var ids []int
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ids) > MaxNameIDs {
+			t.Errorf("request has %d IDs, want at most %d", len(ids), MaxNameIDs)
+		}
+
+		var names []string
+		for _, id := range ids {
+			if bad[id] {
+				http.Error(w, `{"error": "Ensure all IDs are valid before resolving."}`, http.StatusNotFound)
+				return
+			}
+
+			names = append(names, fmt.Sprintf(`{"category": "character", "id": %d, "name": "Pilot %d"}`, id, id))
+		}
+
+		fmt.Fprintf(w, "[%s]", strings.Join(names, ","))
+	}
+}
+
+func TestNameResolver_Resolve(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/v3/universe/names/", namesHandler(t, nil, &calls))
+
+	names, err := client.Names.Resolve(context.Background(), []int{1, 2, 1})
+	if err != nil {
+		t.Fatalf("Names.Resolve returned error: %v", err)
+	}
+
+	want := map[int]Name{
+		1: {ID: 1, Name: "Pilot 1", Category: CategoryCharacter},
+		2: {ID: 2, Name: "Pilot 2", Category: CategoryCharacter},
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Names.Resolve returned %+v, want %+v", names, want)
+	}
+
+	// cached
+	if _, err := client.Names.Resolve(context.Background(), []int{2, 1}); err != nil {
+		t.Fatalf("Names.Resolve returned error: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Names.Resolve made %d calls, want 1", calls)
+	}
+}
+
+func TestNameResolver_Resolve_chunks(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/v3/universe/names/", namesHandler(t, nil, &calls))
+
+	ids := make([]int, 2500)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	names, err := client.Names.Resolve(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("Names.Resolve returned error: %v", err)
+	}
+
+	if len(names) != 2500 {
+		t.Errorf("Names.Resolve returned %d names, want 2500", len(names))
+	}
+
+	if calls != 3 {
+		t.Errorf("Names.Resolve made %d calls, want 3", calls)
+	}
+}
+
+func TestNameResolver_Resolve_bisect(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/v3/universe/names/", namesHandler(t, map[int]bool{3: true, 6: true}, &calls))
+
+	names, err := client.Names.Resolve(context.Background(), []int{1, 2, 3, 4, 5, 6, 7, 8})
+
+	var unresolved *UnresolvedError
+	if !errors.As(err, &unresolved) {
+		t.Fatalf("Names.Resolve returned error %v, want *UnresolvedError", err)
+	}
+
+	if want := []int{3, 6}; !reflect.DeepEqual(unresolved.IDs, want) {
+		t.Errorf("UnresolvedError.IDs = %v, want %v", unresolved.IDs, want)
+	}
+
+	if len(names) != 6 || names[8].Name != "Pilot 8" {
+		t.Errorf("Names.Resolve returned %+v", names)
+	}
+
+	// invalid IDs are cached too
+	calls = 0
+	if _, err := client.Names.Resolve(context.Background(), []int{3, 6, 8}); !errors.As(err, &unresolved) {
+		t.Errorf("Names.Resolve returned error %v, want *UnresolvedError", err)
+	}
+
+	if calls != 0 {
+		t.Errorf("Names.Resolve made %d calls, want 0", calls)
+	}
+}
+
+func TestNameResolver_Resolve_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/universe/names/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "forbidden"}`, http.StatusForbidden)
+	})
+
+	_, err := client.Names.Resolve(context.Background(), []int{1, 2})
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("Names.Resolve returned error %v, want ErrForbidden", err)
+	}
+}
+
+func TestNameResolver_Lookup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/universe/ids/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `["Jita","CCP Bartender"]`+"\n")
+		fmt.Fprint(w, `
+			{
+				"characters": [{"id": 95465499, "name": "CCP Bartender"}],
+				"systems": [{"id": 30000142, "name": "Jita"}]
+			}
+		`)
+	})
+
+	mux.HandleFunc("/v3/universe/names/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected names request")
+	})
+
+	ids, err := client.Names.Lookup(context.Background(), []string{"Jita", "CCP Bartender", "Jita"})
+	if err != nil {
+		t.Fatalf("Names.Lookup returned error: %v", err)
+	}
+
+	want := map[string][]Name{
+		"Jita":          {{ID: 30000142, Name: "Jita", Category: CategorySolarSystem}},
+		"CCP Bartender": {{ID: 95465499, Name: "CCP Bartender", Category: CategoryCharacter}},
+	}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("Names.Lookup returned %+v, want %+v", ids, want)
+	}
+
+	// looked up names are cached for Resolve
+	if _, err := client.Names.Resolve(context.Background(), []int{30000142}); err != nil {
+		t.Errorf("Names.Resolve returned error: %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package esi
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the default maximum number of attempts made by a
+	// RetryPolicy.
+	DefaultMaxAttempts = 3
+
+	// DefaultMinBackoff is the default delay before the first retry.
+	DefaultMinBackoff = 500 * time.Millisecond
+
+	// DefaultMaxBackoff is the default upper bound on the delay between
+	// retries.
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// A RetryPolicy configures how failed requests are retried. Delays between
+// attempts grow exponentially from MinBackoff up to MaxBackoff with random
+// jitter applied. If ESI includes a Retry-After header in the response, the
+// delay is at least as long as requested.
+//
+// Requests with non-idempotent methods (such as POST) are only retried if
+// RetryNonIdempotent is set. Retries are not made if doing so would bring the
+// number of remaining errors in the error limit window below the client's
+// ErrorLimitThreshold.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first. If
+	// zero, DefaultMaxAttempts is used.
+	MaxAttempts int
+
+	// MinBackoff and MaxBackoff bound the delay between attempts. If zero,
+	// DefaultMinBackoff and DefaultMaxBackoff are used.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Retryable reports whether a failed attempt should be retried. If nil,
+	// DefaultRetryable is used.
+	Retryable func(resp *Response, err error) bool
+
+	// RetryNonIdempotent allows requests with non-idempotent methods to be
+	// retried.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryable reports whether a failed attempt is worth retrying. Errors
+// from the transport are retried, as are the 502, 503 and 504 status codes
+// and 500 responses caused by backend timeouts. Other errors, including error
+// limiting, are not retried.
+func DefaultRetryable(resp *Response, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if resp == nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusInternalServerError:
+		return errors.Is(err, ErrTimeout)
+	}
+
+	return false
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+
+	return DefaultMaxAttempts
+}
+
+func (p *RetryPolicy) retryable(resp *Response, err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(resp, err)
+	}
+
+	return DefaultRetryable(resp, err)
+}
+
+// backoff returns the delay before the next attempt after the given attempt
+// number.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	min, max := p.MinBackoff, p.MaxBackoff
+	if min <= 0 {
+		min = DefaultMinBackoff
+	}
+
+	if max <= 0 {
+		max = DefaultMaxBackoff
+	}
+
+	d := min << uint(attempt-1)
+	if d > max || d <= 0 {
+		d = max
+	}
+
+	// equal jitter; wait at least half of the backoff
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+
+	return false
+}
+
+// shouldRetry reports whether the request should be retried after the given
+// attempt, and if so, how long to wait.
+func (api *Client) shouldRetry(ctx context.Context, req *http.Request, resp *Response, err error, attempt int) (time.Duration, bool) {
+	p := api.Retry
+	if p == nil || err == nil || ctx.Err() != nil {
+		return 0, false
+	}
+
+	if attempt >= p.maxAttempts() || !p.retryable(resp, err) {
+		return 0, false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 0, false
+	}
+
+	// the body cannot be replayed
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+
+	// do not spend the remaining error budget on retries
+	if rate := api.Rate(); api.ErrorLimitThreshold > 0 && rate.Reset.After(now()) && rate.Remaining <= api.ErrorLimitThreshold {
+		return 0, false
+	}
+
+	delay := p.backoff(attempt)
+	if resp != nil {
+		if d := parseRetryAfter(resp.Header.Get("Retry-After")); d > delay {
+			delay = d
+		}
+	}
+
+	return delay, true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(s string) time.Duration {
+	if s == "" {
+		return 0
+	}
+
+	if v, err := strconv.Atoi(s); err == nil && v > 0 {
+		return time.Duration(v) * time.Second
+	}
+
+	if t := parseHTTPTime(s); !t.IsZero() {
+		return t.Sub(now())
+	}
+
+	return 0
+}
+
+// sleep waits for the duration d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,285 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  2 * time.Millisecond,
+	}
+}
+
+func TestDo_retry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Retry = testRetryPolicy()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if calls == 1 {
+			http.Error(w, `{"error":"Bad gateway"}`, 502)
+			return
+		}
+
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	body := new(struct{ A string })
+	if _, err := client.Do(context.Background(), req, body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected 2 calls to the server; got %d", calls)
+	}
+
+	if body.A != "a" {
+		t.Errorf("unexpected body; got %+v", body)
+	}
+}
+
+func TestDo_retryReplaysBody(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Retry = testRetryPolicy()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"motd":"some motd"}`+"\n")
+
+		if calls < 3 {
+			http.Error(w, `{"error":"Service unavailable"}`, 503)
+		}
+	})
+
+	req, _ := client.NewRequest("PUT", ".", &FleetSettings{MOTD: "some motd"})
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected 3 calls to the server; got %d", calls)
+	}
+}
+
+func TestDo_retryETag(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Retry = testRetryPolicy()
+	client.ETags = NewMemoryETagStore()
+
+	var tags []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		tags = append(tags, r.Header.Get("If-None-Match"))
+
+		switch {
+		case len(tags)%2 == 1:
+			http.Error(w, `{"error":"Bad gateway"}`, 502)
+		case r.Header.Get("If-None-Match") == `"abc"`:
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Header().Set("ETag", `"abc"`)
+			fmt.Fprint(w, `{"A":"a"}`)
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		req, _ := client.NewRequest("GET", ".", nil)
+		body := new(struct{ A string })
+		resp, err := client.Do(context.Background(), req, body)
+		if err != nil {
+			t.Fatalf("Do #%d returned error: %v", i+1, err)
+		}
+
+		if resp.NotModified != (i == 1) {
+			t.Errorf("Do #%d returned NotModified %v", i+1, resp.NotModified)
+		}
+
+		if body.A != "a" {
+			t.Errorf("Do #%d returned body %+v", i+1, body)
+		}
+	}
+
+	// every attempt of the second call is conditional
+	if want := []string{"", "", `"abc"`, `"abc"`}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("server received If-None-Match %q, want %q", tags, want)
+	}
+}
+
+func TestDo_retryMaxAttempts(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Retry = testRetryPolicy()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"error":"Timeout contacting tranquility","timeout":10}`, 504)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if resp.StatusCode != 504 {
+		t.Errorf("unexpected status code; got %d", resp.StatusCode)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected 3 calls to the server; got %d", calls)
+	}
+}
+
+func TestDo_retryNonIdempotent(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Retry = testRetryPolicy()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"error":"Bad gateway"}`, 502)
+	})
+
+	req, _ := client.NewRequest("POST", ".", &FleetInvitation{CharacterID: 42})
+	client.Do(context.Background(), req, nil)
+
+	if calls != 1 {
+		t.Errorf("expected POST not to be retried; got %d calls", calls)
+	}
+
+	calls = 0
+	client.Retry.RetryNonIdempotent = true
+
+	req, _ = client.NewRequest("POST", ".", &FleetInvitation{CharacterID: 42})
+	client.Do(context.Background(), req, nil)
+
+	if calls != 3 {
+		t.Errorf("expected POST to be retried when opted in; got %d calls", calls)
+	}
+}
+
+func TestDo_retryErrorBudget(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Retry = testRetryPolicy()
+	client.ErrorLimitThreshold = 5
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		w.Header().Set("X-ESI-Error-Limit-Remain", "3")
+		w.Header().Set("X-ESI-Error-Limit-Reset", "60")
+		http.Error(w, `{"error":"Bad gateway"}`, 502)
+	})
+
+	req, _ := client.NewRequest("GET", ".", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected no retries when error budget is low; got %d calls", calls)
+	}
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	respWithStatus := func(code int) *Response {
+		return &Response{Response: &http.Response{StatusCode: code}}
+	}
+
+	var tests = []struct {
+		resp *Response
+		err  error
+		want bool
+	}{
+		{respWithStatus(200), nil, false},
+		{nil, fmt.Errorf("connection reset"), true},
+		{respWithStatus(400), &Error{HTTPStatusCode: 400}, false},
+		{respWithStatus(404), &Error{HTTPStatusCode: 404}, false},
+		{respWithStatus(420), &ErrorLimitedError{Err: &Error{HTTPStatusCode: 420}}, false},
+		{respWithStatus(500), &Error{HTTPStatusCode: 500}, false},
+		{respWithStatus(500), &Error{HTTPStatusCode: 500, Timeout: 10}, true},
+		{respWithStatus(502), &Error{HTTPStatusCode: 502}, true},
+		{respWithStatus(503), &Error{HTTPStatusCode: 503}, true},
+		{respWithStatus(504), &Error{HTTPStatusCode: 504}, true},
+	}
+
+	for i, tt := range tests {
+		if got := DefaultRetryable(tt.resp, tt.err); got != tt.want {
+			t.Errorf("%d. DefaultRetryable(%v) => %v, want %v", i, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	var tests = []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for i, tt := range tests {
+		if d := p.backoff(tt.attempt); d < tt.min || d > tt.max {
+			t.Errorf("%d. backoff(%d) => %v, want between %v and %v", i, tt.attempt, d, tt.min, tt.max)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	staticNow := time.Date(2018, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	oldtime := now
+	now = func() time.Time {
+		return staticNow
+	}
+
+	defer func() {
+		now = oldtime
+	}()
+
+	var tests = []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"invalid", 0},
+		{"5", 5 * time.Second},
+		{"Mon, 01 Jan 2018 18:00:30 GMT", 30 * time.Second},
+	}
+
+	for i, tt := range tests {
+		if got := parseRetryAfter(tt.in); got != tt.want {
+			t.Errorf("%d. parseRetryAfter(%q) => %v, want %v", i, tt.in, got, tt.want)
+		}
+	}
+}
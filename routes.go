@@ -0,0 +1,196 @@
+package esi
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Route version aliases supported by ESI.
+const (
+	// VersionLatest selects the latest stable version of a route.
+	VersionLatest = "latest"
+
+	// VersionLegacy selects the previous stable version of a route.
+	VersionLegacy = "legacy"
+
+	// VersionDev selects the development version of a route. It may change
+	// without notice.
+	VersionDev = "dev"
+)
+
+// A route is an ESI route as known by this library.
+type route struct {
+	// version is the version the library was written against.
+	version string
+
+	// path is a format string for the path relative to the version.
+	path string
+}
+
+// routes maps ESI operation IDs to routes.
+var routes = map[string]route{
+	// alliances
+	"get_alliances":                          {"v1", "alliances/"},
+	"get_alliances_alliance_id":              {"v3", "alliances/%d/"},
+	"get_alliances_alliance_id_corporations": {"v1", "alliances/%d/corporations/"},
+	"get_alliances_alliance_id_icons":        {"v1", "alliances/%d/icons/"},
+
+	// characters
+	"get_characters_character_id":                        {"v1", "characters/%d/"},
+	"get_characters_character_id_agents_research":        {"v1", "characters/%d/agents_research/"},
+	"get_characters_character_id_assets":                 {"v3", "characters/%d/assets/"},
+	"get_characters_character_id_blueprints":             {"v2", "characters/%d/blueprints/"},
+	"get_characters_character_id_corporationhistory":     {"v1", "characters/%d/corporationhistory/"},
+	"get_characters_character_id_fatigue":                {"v1", "characters/%d/fatigue/"},
+	"get_characters_character_id_fleet":                  {"v1", "characters/%d/fleet/"},
+	"get_characters_character_id_medals":                 {"v1", "characters/%d/medals/"},
+	"get_characters_character_id_notifications":          {"v2", "characters/%d/notifications/"},
+	"get_characters_character_id_notifications_contacts": {"v1", "characters/%d/notifications/contacts/"},
+	"get_characters_character_id_orders":                 {"v2", "characters/%d/orders/"},
+	"get_characters_character_id_orders_history":         {"v1", "characters/%d/orders/history/"},
+	"get_characters_character_id_portrait":               {"v2", "characters/%d/portrait/"},
+	"get_characters_character_id_roles":                  {"v2", "characters/%d/roles/"},
+	"get_characters_character_id_standings":              {"v1", "characters/%d/standings/"},
+	"get_characters_character_id_titles":                 {"v1", "characters/%d/titles/"},
+	"get_characters_character_id_wallet":                 {"v1", "characters/%d/wallet/"},
+	"get_characters_character_id_wallet_journal":         {"v6", "characters/%d/wallet/journal/"},
+	"get_characters_character_id_wallet_transactions":    {"v1", "characters/%d/wallet/transactions/"},
+	"post_characters_affiliation":                        {"v1", "characters/affiliation/"},
+	"post_characters_character_id_assets_locations":      {"v2", "characters/%d/assets/locations/"},
+	"post_characters_character_id_assets_names":          {"v1", "characters/%d/assets/names/"},
+
+	// corporations
+	"get_corporations_corporation_id":                               {"v4", "corporations/%d/"},
+	"get_corporations_corporation_id_alliancehistory":               {"v2", "corporations/%d/alliancehistory/"},
+	"get_corporations_corporation_id_assets":                        {"v3", "corporations/%d/assets/"},
+	"get_corporations_corporation_id_containers_logs":               {"v2", "corporations/%d/containers/logs/"},
+	"get_corporations_corporation_id_divisions":                     {"v1", "corporations/%d/divisions/"},
+	"get_corporations_corporation_id_facilities":                    {"v1", "corporations/%d/facilities/"},
+	"get_corporations_corporation_id_medals":                        {"v1", "corporations/%d/medals/"},
+	"get_corporations_corporation_id_medals_issued":                 {"v1", "corporations/%d/medals/issued/"},
+	"get_corporations_corporation_id_members":                       {"v3", "corporations/%d/members/"},
+	"get_corporations_corporation_id_members_titles":                {"v1", "corporations/%d/members/titles/"},
+	"get_corporations_corporation_id_membertracking":                {"v1", "corporations/%d/membertracking/"},
+	"get_corporations_corporation_id_orders":                        {"v3", "corporations/%d/orders/"},
+	"get_corporations_corporation_id_orders_history":                {"v2", "corporations/%d/orders/history/"},
+	"get_corporations_corporation_id_roles":                         {"v1", "corporations/%d/roles/"},
+	"get_corporations_corporation_id_roles_history":                 {"v1", "corporations/%d/roles/history/"},
+	"get_corporations_corporation_id_shareholders":                  {"v1", "corporations/%d/shareholders/"},
+	"get_corporations_corporation_id_starbases":                     {"v1", "corporations/%d/starbases/"},
+	"get_corporations_corporation_id_starbases_starbase_id":         {"v1", "corporations/%d/starbases/%d/"},
+	"get_corporations_corporation_id_structures":                    {"v3", "corporations/%d/structures/"},
+	"get_corporations_corporation_id_titles":                        {"v1", "corporations/%d/titles/"},
+	"get_corporations_corporation_id_wallets":                       {"v1", "corporations/%d/wallets/"},
+	"get_corporations_corporation_id_wallets_division_journal":      {"v4", "corporations/%d/wallets/%d/journal/"},
+	"get_corporations_corporation_id_wallets_division_transactions": {"v1", "corporations/%d/wallets/%d/transactions/"},
+	"get_corporations_npccorps":                                     {"v1", "corporations/npccorps/"},
+	"post_corporations_corporation_id_assets_locations":             {"v2", "corporations/%d/assets/locations/"},
+	"post_corporations_corporation_id_assets_names":                 {"v1", "corporations/%d/assets/names/"},
+
+	// fleets
+	"get_fleets_fleet_id":                       {"v1", "fleets/%d/"},
+	"put_fleets_fleet_id":                       {"v1", "fleets/%d/"},
+	"get_fleets_fleet_id_members":               {"v1", "fleets/%d/members/"},
+	"post_fleets_fleet_id_members":              {"v1", "fleets/%d/members/"},
+	"delete_fleets_fleet_id_members_member_id":  {"v1", "fleets/%d/members/%d/"},
+	"put_fleets_fleet_id_members_member_id":     {"v1", "fleets/%d/members/%d/"},
+	"delete_fleets_fleet_id_squads_squad_id":    {"v1", "fleets/%d/squads/%d/"},
+	"put_fleets_fleet_id_squads_squad_id":       {"v1", "fleets/%d/squads/%d/"},
+	"get_fleets_fleet_id_wings":                 {"v1", "fleets/%d/wings/"},
+	"post_fleets_fleet_id_wings":                {"v1", "fleets/%d/wings/"},
+	"delete_fleets_fleet_id_wings_wing_id":      {"v1", "fleets/%d/wings/%d/"},
+	"put_fleets_fleet_id_wings_wing_id":         {"v1", "fleets/%d/wings/%d/"},
+	"post_fleets_fleet_id_wings_wing_id_squads": {"v1", "fleets/%d/wings/%d/squads/"},
+
+	// markets
+	"get_markets_groups":                  {"v1", "markets/groups/"},
+	"get_markets_groups_market_group_id":  {"v1", "markets/groups/%d/"},
+	"get_markets_prices":                  {"v1", "markets/prices/"},
+	"get_markets_region_id_history":       {"v1", "markets/%d/history/"},
+	"get_markets_region_id_orders":        {"v1", "markets/%d/orders/"},
+	"get_markets_region_id_types":         {"v1", "markets/%d/types/"},
+	"get_markets_structures_structure_id": {"v1", "markets/structures/%d/"},
+
+	// universe
+	"get_universe_ancestries":                      {"v1", "universe/ancestries/"},
+	"get_universe_asteroid_belts_asteroid_belt_id": {"v1", "universe/asteroid_belts/%d/"},
+	"get_universe_bloodlines":                      {"v1", "universe/bloodlines/"},
+	"get_universe_categories":                      {"v1", "universe/categories/"},
+	"get_universe_categories_category_id":          {"v1", "universe/categories/%d/"},
+	"get_universe_constellations":                  {"v1", "universe/constellations/"},
+	"get_universe_constellations_constellation_id": {"v1", "universe/constellations/%d/"},
+	"get_universe_factions":                        {"v2", "universe/factions/"},
+	"get_universe_graphics":                        {"v1", "universe/graphics/"},
+	"get_universe_graphics_graphic_id":             {"v1", "universe/graphics/%d/"},
+	"get_universe_groups":                          {"v1", "universe/groups/"},
+	"get_universe_groups_group_id":                 {"v1", "universe/groups/%d/"},
+	"get_universe_moons_moon_id":                   {"v1", "universe/moons/%d/"},
+	"get_universe_planets_planet_id":               {"v1", "universe/planets/%d/"},
+	"get_universe_races":                           {"v1", "universe/races/"},
+	"get_universe_regions":                         {"v1", "universe/regions/"},
+	"get_universe_regions_region_id":               {"v1", "universe/regions/%d/"},
+	"get_universe_stargates_stargate_id":           {"v1", "universe/stargates/%d/"},
+	"get_universe_stars_star_id":                   {"v1", "universe/stars/%d/"},
+	"get_universe_stations_station_id":             {"v2", "universe/stations/%d/"},
+	"get_universe_structures":                      {"v1", "universe/structures/"},
+	"get_universe_structures_structure_id":         {"v2", "universe/structures/%d/"},
+	"get_universe_system_jumps":                    {"v1", "universe/system_jumps/"},
+	"get_universe_system_kills":                    {"v2", "universe/system_kills/"},
+	"get_universe_systems":                         {"v1", "universe/systems/"},
+	"get_universe_systems_system_id":               {"v4", "universe/systems/%d/"},
+	"get_universe_types":                           {"v1", "universe/types/"},
+	"get_universe_types_type_id":                   {"v3", "universe/types/%d/"},
+	"post_universe_ids":                            {"v1", "universe/ids/"},
+	"post_universe_names":                          {"v3", "universe/names/"},
+}
+
+// routeVersion returns the version to use for the named route. Per-route
+// overrides in Routes take precedence over the client-wide Version, which in
+// turn takes precedence over the version the library was written against.
+func (api *Client) routeVersion(name string) string {
+	if v, ok := api.Routes[name]; ok && v != "" {
+		return v
+	}
+
+	if api.Version != "" {
+		return api.Version
+	}
+
+	return routes[name].version
+}
+
+// route returns the URL, relative to the BaseURL, of the named route with
+// args substituted into the path. It panics if the route is unknown.
+func (api *Client) route(name string, args ...interface{}) string {
+	r, ok := routes[name]
+	if !ok {
+		panic("esi: unknown route " + name)
+	}
+
+	return api.routeVersion(name) + "/" + fmt.Sprintf(r.path, args...)
+}
+
+var versionRegexp = regexp.MustCompile(`^(v[0-9]+|latest|legacy|dev)$`)
+
+// servedVersion returns the route version in the path of u, which is the URL
+// of the request that was actually served (after any redirects).
+func (api *Client) servedVersion(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	path := strings.TrimPrefix(u.Path, api.BaseURL.Path)
+	path = strings.TrimPrefix(path, "/")
+
+	if i := strings.Index(path, "/"); i >= 0 {
+		path = path[:i]
+	}
+
+	if !versionRegexp.MatchString(path) {
+		return ""
+	}
+
+	return path
+}
@@ -0,0 +1,103 @@
+package esi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClient_route(t *testing.T) {
+	c := NewClient(nil)
+
+	if got, want := c.route("get_fleets_fleet_id", 42), "v1/fleets/42/"; got != want {
+		t.Errorf("route() => %q, want %q", got, want)
+	}
+
+	c.Version = VersionLatest
+	if got, want := c.route("get_fleets_fleet_id", 42), "latest/fleets/42/"; got != want {
+		t.Errorf("route() with Version %q => %q, want %q", c.Version, got, want)
+	}
+
+	c.Routes = map[string]string{"get_fleets_fleet_id": "v2"}
+	if got, want := c.route("get_fleets_fleet_id", 42), "v2/fleets/42/"; got != want {
+		t.Errorf("route() with override => %q, want %q", got, want)
+	}
+
+	if got, want := c.route("get_fleets_fleet_id_wings", 42), "latest/fleets/42/wings/"; got != want {
+		t.Errorf("route() without override => %q, want %q", got, want)
+	}
+}
+
+func TestClient_route_unknown(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	NewClient(nil).route("get_unknown_route")
+}
+
+func TestDo_version(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Version = VersionLegacy
+
+	mux.HandleFunc("/legacy/fleets/42/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+	})
+
+	_, resp, err := client.Fleets.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Fleets.Get returned error: %v", err)
+	}
+
+	if resp.Version != VersionLegacy {
+		t.Errorf("unexpected Version; got %q, want %q", resp.Version, VersionLegacy)
+	}
+}
+
+func TestDo_versionRedirected(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Version = VersionLatest
+
+	mux.HandleFunc("/latest/fleets/42/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, baseURLPath+"/v2/fleets/42/", http.StatusFound)
+	})
+
+	mux.HandleFunc("/v2/fleets/42/", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, resp, err := client.Fleets.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Fleets.Get returned error: %v", err)
+	}
+
+	if resp.Version != "v2" {
+		t.Errorf("unexpected Version; got %q, want %q", resp.Version, "v2")
+	}
+}
+
+func TestClient_servedVersion(t *testing.T) {
+	c := NewClient(nil)
+
+	var tests = []struct {
+		in   string
+		want string
+	}{
+		{"https://esi.evetech.net/v1/fleets/42/", "v1"},
+		{"https://esi.evetech.net/dev/fleets/42/", "dev"},
+		{"https://esi.evetech.net/fleets/42/", ""},
+		{"https://esi.evetech.net/", ""},
+	}
+
+	for i, tt := range tests {
+		u, _ := url.Parse(tt.in)
+		if got := c.servedVersion(u); got != tt.want {
+			t.Errorf("%d. servedVersion(%q) => %q, want %q", i, tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SSO scopes required by authenticated routes.
+const (
+	ScopeAssetsReadAssets                      = "esi-assets.read_assets.v1"
+	ScopeAssetsReadCorporationAssets           = "esi-assets.read_corporation_assets.v1"
+	ScopeCharactersReadAgentsResearch          = "esi-characters.read_agents_research.v1"
+	ScopeCharactersReadBlueprints              = "esi-characters.read_blueprints.v1"
+	ScopeCharactersReadCorporationRoles        = "esi-characters.read_corporation_roles.v1"
+	ScopeCharactersReadFatigue                 = "esi-characters.read_fatigue.v1"
+	ScopeCharactersReadMedals                  = "esi-characters.read_medals.v1"
+	ScopeCharactersReadNotifications           = "esi-characters.read_notifications.v1"
+	ScopeCharactersReadStandings               = "esi-characters.read_standings.v1"
+	ScopeCharactersReadTitles                  = "esi-characters.read_titles.v1"
+	ScopeCorporationsReadContainerLogs         = "esi-corporations.read_container_logs.v1"
+	ScopeCorporationsReadCorporationMembership = "esi-corporations.read_corporation_membership.v1"
+	ScopeCorporationsReadDivisions             = "esi-corporations.read_divisions.v1"
+	ScopeCorporationsReadFacilities            = "esi-corporations.read_facilities.v1"
+	ScopeCorporationsReadMedals                = "esi-corporations.read_medals.v1"
+	ScopeCorporationsReadStarbases             = "esi-corporations.read_starbases.v1"
+	ScopeCorporationsReadStructures            = "esi-corporations.read_structures.v1"
+	ScopeCorporationsReadTitles                = "esi-corporations.read_titles.v1"
+	ScopeCorporationsTrackMembers              = "esi-corporations.track_members.v1"
+	ScopeFleetsReadFleet                       = "esi-fleets.read_fleet.v1"
+	ScopeFleetsWriteFleet                      = "esi-fleets.write_fleet.v1"
+	ScopeMarketsReadCharacterOrders            = "esi-markets.read_character_orders.v1"
+	ScopeMarketsReadCorporationOrders          = "esi-markets.read_corporation_orders.v1"
+	ScopeMarketsStructureMarkets               = "esi-markets.structure_markets.v1"
+	ScopeUniverseReadStructures                = "esi-universe.read_structures.v1"
+	ScopeWalletReadCharacterWallet             = "esi-wallet.read_character_wallet.v1"
+	ScopeWalletReadCorporationWallets          = "esi-wallet.read_corporation_wallets.v1"
+)
+
+// A requirement is what an authenticated route requires of the caller.
+type requirement struct {
+	// scope is the SSO scope the access token must be granted.
+	scope string
+
+	// roles lists the corporation roles, any of which the character must
+	// have in game. It is nil for routes that need no roles.
+	roles []string
+}
+
+// requirements maps the ESI operation IDs of authenticated routes to their
+// requirements. Routes not listed are public.
+var requirements = map[string]requirement{
+	// characters
+	"get_characters_character_id_agents_research":        {ScopeCharactersReadAgentsResearch, nil},
+	"get_characters_character_id_assets":                 {ScopeAssetsReadAssets, nil},
+	"get_characters_character_id_blueprints":             {ScopeCharactersReadBlueprints, nil},
+	"get_characters_character_id_fatigue":                {ScopeCharactersReadFatigue, nil},
+	"get_characters_character_id_fleet":                  {ScopeFleetsReadFleet, nil},
+	"get_characters_character_id_medals":                 {ScopeCharactersReadMedals, nil},
+	"get_characters_character_id_notifications":          {ScopeCharactersReadNotifications, nil},
+	"get_characters_character_id_notifications_contacts": {ScopeCharactersReadNotifications, nil},
+	"get_characters_character_id_orders":                 {ScopeMarketsReadCharacterOrders, nil},
+	"get_characters_character_id_orders_history":         {ScopeMarketsReadCharacterOrders, nil},
+	"get_characters_character_id_roles":                  {ScopeCharactersReadCorporationRoles, nil},
+	"get_characters_character_id_standings":              {ScopeCharactersReadStandings, nil},
+	"get_characters_character_id_titles":                 {ScopeCharactersReadTitles, nil},
+	"get_characters_character_id_wallet":                 {ScopeWalletReadCharacterWallet, nil},
+	"get_characters_character_id_wallet_journal":         {ScopeWalletReadCharacterWallet, nil},
+	"get_characters_character_id_wallet_transactions":    {ScopeWalletReadCharacterWallet, nil},
+	"post_characters_character_id_assets_locations":      {ScopeAssetsReadAssets, nil},
+	"post_characters_character_id_assets_names":          {ScopeAssetsReadAssets, nil},
+
+	// corporations
+	"get_corporations_corporation_id_assets":                        {ScopeAssetsReadCorporationAssets, []string{"Director"}},
+	"get_corporations_corporation_id_containers_logs":               {ScopeCorporationsReadContainerLogs, []string{"Director", "Station_Manager"}},
+	"get_corporations_corporation_id_divisions":                     {ScopeCorporationsReadDivisions, []string{"Director"}},
+	"get_corporations_corporation_id_facilities":                    {ScopeCorporationsReadFacilities, []string{"Factory_Manager"}},
+	"get_corporations_corporation_id_medals":                        {ScopeCorporationsReadMedals, nil},
+	"get_corporations_corporation_id_medals_issued":                 {ScopeCorporationsReadMedals, []string{"Director"}},
+	"get_corporations_corporation_id_members":                       {ScopeCorporationsReadCorporationMembership, nil},
+	"get_corporations_corporation_id_members_titles":                {ScopeCorporationsReadTitles, []string{"Director"}},
+	"get_corporations_corporation_id_membertracking":                {ScopeCorporationsTrackMembers, []string{"Director"}},
+	"get_corporations_corporation_id_orders":                        {ScopeMarketsReadCorporationOrders, []string{"Accountant", "Trader"}},
+	"get_corporations_corporation_id_orders_history":                {ScopeMarketsReadCorporationOrders, []string{"Accountant", "Trader"}},
+	"get_corporations_corporation_id_roles":                         {ScopeCorporationsReadCorporationMembership, nil},
+	"get_corporations_corporation_id_roles_history":                 {ScopeCorporationsReadCorporationMembership, []string{"Director"}},
+	"get_corporations_corporation_id_shareholders":                  {ScopeWalletReadCorporationWallets, []string{"Director"}},
+	"get_corporations_corporation_id_starbases":                     {ScopeCorporationsReadStarbases, []string{"Director"}},
+	"get_corporations_corporation_id_starbases_starbase_id":         {ScopeCorporationsReadStarbases, []string{"Director"}},
+	"get_corporations_corporation_id_structures":                    {ScopeCorporationsReadStructures, []string{"Station_Manager"}},
+	"get_corporations_corporation_id_titles":                        {ScopeCorporationsReadTitles, []string{"Director"}},
+	"get_corporations_corporation_id_wallets":                       {ScopeWalletReadCorporationWallets, []string{"Accountant", "Junior_Accountant"}},
+	"get_corporations_corporation_id_wallets_division_journal":      {ScopeWalletReadCorporationWallets, []string{"Accountant", "Junior_Accountant"}},
+	"get_corporations_corporation_id_wallets_division_transactions": {ScopeWalletReadCorporationWallets, []string{"Accountant", "Junior_Accountant"}},
+	"post_corporations_corporation_id_assets_locations":             {ScopeAssetsReadCorporationAssets, []string{"Director"}},
+	"post_corporations_corporation_id_assets_names":                 {ScopeAssetsReadCorporationAssets, []string{"Director"}},
+
+	// fleets
+	"get_fleets_fleet_id":                       {ScopeFleetsReadFleet, nil},
+	"put_fleets_fleet_id":                       {ScopeFleetsWriteFleet, nil},
+	"get_fleets_fleet_id_members":               {ScopeFleetsReadFleet, nil},
+	"post_fleets_fleet_id_members":              {ScopeFleetsWriteFleet, nil},
+	"delete_fleets_fleet_id_members_member_id":  {ScopeFleetsWriteFleet, nil},
+	"put_fleets_fleet_id_members_member_id":     {ScopeFleetsWriteFleet, nil},
+	"delete_fleets_fleet_id_squads_squad_id":    {ScopeFleetsWriteFleet, nil},
+	"put_fleets_fleet_id_squads_squad_id":       {ScopeFleetsWriteFleet, nil},
+	"get_fleets_fleet_id_wings":                 {ScopeFleetsReadFleet, nil},
+	"post_fleets_fleet_id_wings":                {ScopeFleetsWriteFleet, nil},
+	"delete_fleets_fleet_id_wings_wing_id":      {ScopeFleetsWriteFleet, nil},
+	"put_fleets_fleet_id_wings_wing_id":         {ScopeFleetsWriteFleet, nil},
+	"post_fleets_fleet_id_wings_wing_id_squads": {ScopeFleetsWriteFleet, nil},
+
+	// markets
+	"get_markets_structures_structure_id": {ScopeMarketsStructureMarkets, nil},
+
+	// universe
+	"get_universe_structures_structure_id": {ScopeUniverseReadStructures, nil},
+}
+
+// RouteRequirements describes what calling a route requires.
+type RouteRequirements struct {
+	// Scopes holds the SSO scopes the access token must be granted. It is
+	// empty for public routes.
+	Scopes []string
+
+	// Roles lists the corporation roles, any of which the character must
+	// have in game. It is empty for routes that need no roles.
+	Roles []string
+}
+
+// Requirements returns the requirements of the route with the given ESI
+// operation ID, such as "put_fleets_fleet_id". The boolean result reports
+// whether the route is known.
+func Requirements(operationID string) (RouteRequirements, bool) {
+	if _, ok := routes[operationID]; !ok {
+		return RouteRequirements{}, false
+	}
+
+	var r RouteRequirements
+	if req, ok := requirements[operationID]; ok {
+		r.Scopes = []string{req.scope}
+		r.Roles = append([]string(nil), req.roles...)
+	}
+
+	return r, true
+}
+
+// MissingScopeError is returned by Client.Do, without making the request, if
+// the route requires scopes that have not been granted. It matches
+// ErrForbidden.
+type MissingScopeError struct {
+	Method string
+
+	// Route is the ESI operation ID of the route.
+	Route string
+
+	// Scopes holds the missing scopes.
+	Scopes []string
+
+	// Roles lists the corporation roles, any of which the character must
+	// also have in game.
+	Roles []string
+}
+
+func (e *MissingScopeError) Error() string {
+	return fmt.Sprintf("esi: %s requires scope %s", e.Route, strings.Join(e.Scopes, ", "))
+}
+
+// Is reports whether target is ErrForbidden.
+func (e *MissingScopeError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+type scopesKey struct{}
+
+// WithScopes returns a copy of ctx that declares scopes as the scopes granted
+// to the access token used for requests carried out with it, overriding the
+// Scopes configured on the Client.
+func WithScopes(ctx context.Context, scopes ...string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, append([]string{}, scopes...))
+}
+
+// grantedScopes returns the scopes granted for requests carried out with ctx,
+// or nil if they are unknown.
+func (api *Client) grantedScopes(ctx context.Context) []string {
+	if scopes, ok := ctx.Value(scopesKey{}).([]string); ok {
+		return scopes
+	}
+
+	return api.Scopes
+}
+
+// checkScopes returns a *MissingScopeError if req is for a route that requires
+// a scope that has not been granted. Requests are not checked if the granted
+// scopes are unknown or the route cannot be determined.
+func (api *Client) checkScopes(ctx context.Context, req *http.Request) error {
+	granted := api.grantedScopes(ctx)
+	if granted == nil {
+		return nil
+	}
+
+	name, ok := api.operationID(req)
+	if !ok {
+		return nil
+	}
+
+	r, ok := requirements[name]
+	if !ok {
+		return nil
+	}
+
+	for _, s := range granted {
+		if s == r.scope {
+			return nil
+		}
+	}
+
+	return &MissingScopeError{
+		Method: req.Method,
+		Route:  name,
+		Scopes: []string{r.scope},
+		Roles:  r.roles,
+	}
+}
+
+// A routePattern matches request paths, relative to the version, against a
+// route. Segments of the route path holding IDs match any number.
+type routePattern struct {
+	name     string
+	method   string
+	segments []string
+}
+
+var routePatterns = compileRoutes()
+
+func compileRoutes() []routePattern {
+	patterns := make([]routePattern, 0, len(routes))
+	for name, r := range routes {
+		method := strings.ToUpper(name[:strings.Index(name, "_")])
+		patterns = append(patterns, routePattern{
+			name:     name,
+			method:   method,
+			segments: strings.Split(strings.Trim(r.path, "/"), "/"),
+		})
+	}
+
+	return patterns
+}
+
+func (p routePattern) match(method string, segments []string) bool {
+	if p.method != method || len(p.segments) != len(segments) {
+		return false
+	}
+
+	for i, s := range p.segments {
+		if s == "%d" {
+			if !isNumber(segments[i]) {
+				return false
+			}
+		} else if s != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// operationID returns the ESI operation ID of the route req is for.
+func (api *Client) operationID(req *http.Request) (string, bool) {
+	if req.URL == nil {
+		return "", false
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, api.BaseURL.Path)
+	path = strings.Trim(path, "/")
+
+	// skip the version
+	i := strings.Index(path, "/")
+	if i < 0 {
+		return "", false
+	}
+
+	segments := strings.Split(path[i+1:], "/")
+	for _, p := range routePatterns {
+		if p.match(req.Method, segments) {
+			return p.name, true
+		}
+	}
+
+	return "", false
+}
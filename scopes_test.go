@@ -0,0 +1,173 @@
+package esi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRequirements(t *testing.T) {
+	r, ok := Requirements("put_fleets_fleet_id")
+	if !ok {
+		t.Fatalf("Requirements returned false for known route")
+	}
+
+	if want := (RouteRequirements{Scopes: []string{ScopeFleetsWriteFleet}}); !reflect.DeepEqual(r, want) {
+		t.Errorf("Requirements returned %+v, want %+v", r, want)
+	}
+
+	r, _ = Requirements("get_corporations_corporation_id_wallets")
+	want := RouteRequirements{
+		Scopes: []string{ScopeWalletReadCorporationWallets},
+		Roles:  []string{"Accountant", "Junior_Accountant"},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("Requirements returned %+v, want %+v", r, want)
+	}
+
+	r, ok = Requirements("get_alliances")
+	if !ok || len(r.Scopes) != 0 || len(r.Roles) != 0 {
+		t.Errorf("Requirements returned %+v, %v for public route", r, ok)
+	}
+
+	if _, ok := Requirements("get_unknown_route"); ok {
+		t.Errorf("Requirements returned true for unknown route")
+	}
+}
+
+func TestRequirements_knownRoutes(t *testing.T) {
+	for name := range requirements {
+		if _, ok := routes[name]; !ok {
+			t.Errorf("requirements for unknown route %s", name)
+		}
+	}
+}
+
+func TestClient_operationID(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	// every route must be recognized from its request
+	for name := range routes {
+		args := make([]interface{}, strings.Count(routes[name].path, "%d"))
+		for i := range args {
+			args[i] = 42 + i
+		}
+
+		method := strings.ToUpper(name[:strings.Index(name, "_")])
+
+		req, err := client.NewRequest(method, client.route(name, args...), nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+
+		if got, ok := client.operationID(req); !ok || got != name {
+			t.Errorf("operationID(%s %s) = %q, %v, want %q", method, req.URL.Path, got, ok, name)
+		}
+	}
+
+	req, _ := client.NewRequest("GET", "v1/unknown/", nil)
+	if got, ok := client.operationID(req); ok {
+		t.Errorf("operationID returned %q for unknown route", got)
+	}
+}
+
+func TestDo_missingScope(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/fleets/42/members/43/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request made despite missing scope")
+	})
+
+	client.Scopes = []string{ScopeFleetsReadFleet}
+
+	_, err := client.Fleets.Kick(context.Background(), 42, 43)
+
+	want := &MissingScopeError{
+		Method: "DELETE",
+		Route:  "delete_fleets_fleet_id_members_member_id",
+		Scopes: []string{ScopeFleetsWriteFleet},
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("Fleets.Kick returned error %#v, want %#v", err, want)
+	}
+
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("MissingScopeError does not match ErrForbidden")
+	}
+
+	if got, want := err.Error(), "esi: delete_fleets_fleet_id_members_member_id requires scope esi-fleets.write_fleet.v1"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestDo_missingScope_roles(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	ctx := WithScopes(context.Background())
+
+	_, _, err := client.Wallet.GetCorporationBalances(ctx, 42)
+
+	var serr *MissingScopeError
+	if !errors.As(err, &serr) {
+		t.Fatalf("GetCorporationBalances returned error %v, want *MissingScopeError", err)
+	}
+
+	if want := []string{"Accountant", "Junior_Accountant"}; !reflect.DeepEqual(serr.Roles, want) {
+		t.Errorf("MissingScopeError.Roles = %v, want %v", serr.Roles, want)
+	}
+}
+
+func TestDo_grantedScope(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/fleets/42/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+	})
+
+	client.Scopes = []string{}
+
+	ctx := WithScopes(context.Background(), ScopeFleetsReadFleet, ScopeFleetsWriteFleet)
+	if _, err := client.Fleets.Update(ctx, 42, &FleetSettings{}); err != nil {
+		t.Errorf("Fleets.Update returned error: %v", err)
+	}
+}
+
+func TestDo_unknownScopes(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	called := false
+	mux.HandleFunc("/v1/fleets/42/", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	if _, err := client.Fleets.Update(context.Background(), 42, &FleetSettings{}); err != nil {
+		t.Errorf("Fleets.Update returned error: %v", err)
+	}
+
+	if !called {
+		t.Errorf("request not made without known scopes")
+	}
+}
+
+func TestDo_publicRoute(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/alliances/42/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	})
+
+	client.Scopes = []string{}
+
+	if _, _, err := client.Alliances.Get(context.Background(), 42); err != nil {
+		t.Errorf("Alliances.Get returned error: %v", err)
+	}
+}
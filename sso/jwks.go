@@ -0,0 +1,214 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// JWKSURL is the URL of the key set used to sign EVE SSO v2 access tokens.
+const JWKSURL = "https://login.eveonline.com/oauth/jwks"
+
+// DefaultKeySetTTL is the time a KeySet keeps fetched keys.
+const DefaultKeySetTTL = 12 * time.Hour
+
+// A KeySet is a cached JSON Web Key Set. Keys are fetched when first needed
+// and refetched when they expire or when a token is signed with an unknown
+// key. A KeySet is safe for concurrent use.
+type KeySet struct {
+	// URL is the location of the key set.
+	URL string
+
+	// TTL is the time fetched keys are kept. If zero, DefaultKeySetTTL is
+	// used.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+
+	// refresh is closed when the fetch in progress, if any, completes.
+	refresh chan struct{}
+}
+
+// NewKeySet returns a KeySet for the keys at url.
+func NewKeySet(url string) *KeySet {
+	return &KeySet{URL: url}
+}
+
+// Key returns the public key with the given key ID. The key set is fetched
+// without holding the lock, so lookups of cached keys are not blocked by a
+// refetch, and concurrent refetches are coalesced into one request.
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	ttl := s.TTL
+	if ttl == 0 {
+		ttl = DefaultKeySetTTL
+	}
+
+	for {
+		s.mu.Lock()
+
+		k, ok := s.keys[kid]
+		if ok && time.Since(s.fetched) < ttl {
+			s.mu.Unlock()
+			return k, nil
+		}
+
+		// The keys expired, or an unknown key may have been rotated in.
+		// Refetch, but not more than once a minute for unknown keys.
+		if time.Since(s.fetched) < ttl && time.Since(s.fetched) < time.Minute {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+		}
+
+		// Wait for a fetch already in progress and look again.
+		if ch := s.refresh; ch != nil {
+			s.mu.Unlock()
+
+			select {
+			case <-ch:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		ch := make(chan struct{})
+		s.refresh = ch
+		s.mu.Unlock()
+
+		keys, err := s.fetch(ctx)
+
+		s.mu.Lock()
+		if err == nil {
+			s.keys = keys
+			s.fetched = time.Now()
+		}
+		s.refresh = nil
+		close(ch)
+		s.mu.Unlock()
+
+		if err != nil {
+			return nil, err
+		}
+
+		k, ok = keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+		}
+
+		return k, nil
+	}
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetch returns the keys at s.URL. Keys of unsupported types are ignored.
+func (s *KeySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequest("GET", s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := contextClient(ctx).Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sso: fetching key set: %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("sso: decoding key set: %v", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if pub, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("sso: invalid RSA exponent")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("sso: unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, errors.New("sso: invalid EC key")
+		}
+
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("sso: unsupported key type %q", k.Kty)
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
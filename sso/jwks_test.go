@@ -0,0 +1,178 @@
+package sso
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	testRSAKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	testECKey, _  = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+)
+
+// jwksServer serves a key set holding testRSAKey as "rs" and testECKey as
+// "es", and counts the requests made to it.
+type jwksServer struct {
+	*httptest.Server
+
+	requests int
+}
+
+func newJWKSServer() *jwksServer {
+	s := &jwksServer{}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+
+		enc := func(i *big.Int) string {
+			return base64.RawURLEncoding.EncodeToString(i.Bytes())
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []jwk{
+				{Kid: "rs", Kty: "RSA", Alg: "RS256", N: enc(testRSAKey.N), E: enc(big.NewInt(int64(testRSAKey.E)))},
+				{Kid: "es", Kty: "EC", Alg: "ES256", Crv: "P-256", X: enc(testECKey.X), Y: enc(testECKey.Y)},
+				{Kid: "oct", Kty: "oct"},
+			},
+		})
+	}))
+
+	return s
+}
+
+func TestKeySet_Key(t *testing.T) {
+	s := newJWKSServer()
+	defer s.Close()
+
+	ks := NewKeySet(s.URL)
+
+	k, err := ks.Key(context.Background(), "rs")
+	if err != nil {
+		t.Fatalf("Key returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(k, &testRSAKey.PublicKey) {
+		t.Errorf("Key returned %v, want %v", k, &testRSAKey.PublicKey)
+	}
+
+	k, err = ks.Key(context.Background(), "es")
+	if err != nil {
+		t.Fatalf("Key returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(k, &testECKey.PublicKey) {
+		t.Errorf("Key returned %v, want %v", k, &testECKey.PublicKey)
+	}
+
+	if s.requests != 1 {
+		t.Errorf("key set fetched %d times, want 1", s.requests)
+	}
+}
+
+func TestKeySet_Key_unknown(t *testing.T) {
+	s := newJWKSServer()
+	defer s.Close()
+
+	ks := NewKeySet(s.URL)
+
+	for i := 0; i < 2; i++ {
+		if _, err := ks.Key(context.Background(), "oct"); err == nil {
+			t.Errorf("Key returned no error for unsupported key")
+		}
+	}
+
+	// unknown keys are refetched at most once a minute
+	if s.requests != 1 {
+		t.Errorf("key set fetched %d times, want 1", s.requests)
+	}
+
+	ks.fetched = ks.fetched.Add(-time.Minute)
+	if _, err := ks.Key(context.Background(), "unknown"); err == nil {
+		t.Errorf("Key returned no error for unknown key")
+	}
+
+	if s.requests != 2 {
+		t.Errorf("key set fetched %d times, want 2", s.requests)
+	}
+}
+
+func TestKeySet_Key_expired(t *testing.T) {
+	s := newJWKSServer()
+	defer s.Close()
+
+	ks := NewKeySet(s.URL)
+	ks.TTL = time.Hour
+
+	ks.Key(context.Background(), "rs")
+	ks.fetched = ks.fetched.Add(-time.Hour)
+	ks.Key(context.Background(), "rs")
+
+	if s.requests != 2 {
+		t.Errorf("key set fetched %d times, want 2", s.requests)
+	}
+}
+
+func TestKeySet_Key_concurrentFetch(t *testing.T) {
+	s := newJWKSServer()
+	defer s.Close()
+
+	ks := NewKeySet(s.URL)
+	ks.Key(context.Background(), "rs")
+	ks.fetched = ks.fetched.Add(-time.Minute)
+
+	// block the next fetch until released
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := s.Config.Handler
+	s.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		handler.ServeHTTP(w, r)
+	})
+
+	errc := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := ks.Key(context.Background(), "unknown")
+			errc <- err
+		}()
+	}
+
+	<-started
+
+	// cached keys are served while the key set is refetched
+	if _, err := ks.Key(context.Background(), "rs"); err != nil {
+		t.Errorf("Key returned error: %v", err)
+	}
+
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		if err := <-errc; err == nil {
+			t.Errorf("Key returned no error for unknown key")
+		}
+	}
+
+	if s.requests != 2 {
+		t.Errorf("key set fetched %d times, want 2", s.requests)
+	}
+}
+
+func TestKeySet_Key_httpError(t *testing.T) {
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+
+	if _, err := NewKeySet(s.URL).Key(context.Background(), "rs"); err == nil {
+		t.Errorf("Key returned no error")
+	}
+}
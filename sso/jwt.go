@@ -0,0 +1,255 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned if an access token is malformed, has an
+	// invalid signature or was not issued for the application.
+	ErrInvalidToken = errors.New("sso: invalid token")
+
+	// ErrExpiredToken is returned if an access token has expired.
+	ErrExpiredToken = errors.New("sso: token expired")
+)
+
+// Issuers are the issuers found in EVE SSO v2 access tokens.
+var Issuers = []string{"login.eveonline.com", "https://login.eveonline.com"}
+
+// Audience is the audience every EVE SSO v2 access token is issued for, in
+// addition to the client ID of the application.
+const Audience = "EVE Online"
+
+// TokenClaims holds the claims of a verified access token.
+type TokenClaims struct {
+	// CharacterID is the ID of the character the token was issued for.
+	CharacterID int
+
+	// Name is the name of the character.
+	Name string
+
+	// Owner is the owner hash of the character. It changes when the
+	// character is transferred to another account.
+	Owner string
+
+	// Scopes holds the scopes granted to the token.
+	Scopes []string
+
+	Subject   string
+	Issuer    string
+	Audience  []string
+	KeyID     string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// HasScope reports whether the token was granted scope.
+func (c *TokenClaims) HasScope(scope string) bool {
+	return contains(c.Scopes, scope)
+}
+
+// A Verifier verifies access tokens issued to an application.
+type Verifier struct {
+	// ClientID is the client ID of the application. Tokens issued to other
+	// applications are rejected.
+	ClientID string
+
+	// Keys is the key set tokens must be signed with.
+	Keys *KeySet
+
+	// Leeway is the clock skew allowed when checking the expiry of tokens.
+	Leeway time.Duration
+
+	now func() time.Time
+}
+
+// NewVerifier returns a Verifier for tokens issued to the application with
+// the given client ID, signed with the keys at JWKSURL.
+func NewVerifier(clientID string) *Verifier {
+	return &Verifier{
+		ClientID: clientID,
+		Keys:     NewKeySet(JWKSURL),
+		Leeway:   5 * time.Second,
+	}
+}
+
+// Verifier returns a Verifier for tokens issued to c.
+func (c *Config) Verifier() *Verifier {
+	return NewVerifier(c.ClientID)
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	Scopes    json.RawMessage `json:"scp"`
+	Name      string          `json:"name"`
+	Owner     string          `json:"owner"`
+	ExpiresAt int64           `json:"exp"`
+	IssuedAt  int64           `json:"iat"`
+}
+
+// Verify verifies the signature, issuer, audience and expiry of an access
+// token and returns its claims. Errors match ErrInvalidToken or
+// ErrExpiredToken.
+func (v *Verifier) Verify(ctx context.Context, token string) (*TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+
+	key, err := v.Keys.Key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	tc := &TokenClaims{
+		Name:      claims.Name,
+		Owner:     claims.Owner,
+		Subject:   claims.Subject,
+		Issuer:    claims.Issuer,
+		KeyID:     header.Kid,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}
+
+	if tc.Audience, err = stringOrSlice(claims.Audience); err != nil {
+		return nil, err
+	}
+
+	if tc.Scopes, err = stringOrSlice(claims.Scopes); err != nil {
+		return nil, err
+	}
+
+	if !contains(Issuers, tc.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, tc.Issuer)
+	}
+
+	if !contains(tc.Audience, v.ClientID) || !contains(tc.Audience, Audience) {
+		return nil, fmt.Errorf("%w: unexpected audience %q", ErrInvalidToken, tc.Audience)
+	}
+
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+
+	if claims.ExpiresAt == 0 || now().After(tc.ExpiresAt.Add(v.Leeway)) {
+		return nil, ErrExpiredToken
+	}
+
+	// The subject is of the form "CHARACTER:EVE:<id>".
+	id := strings.TrimPrefix(tc.Subject, "CHARACTER:EVE:")
+	if id == tc.Subject {
+		return nil, fmt.Errorf("%w: unexpected subject %q", ErrInvalidToken, tc.Subject)
+	}
+
+	if tc.CharacterID, err = strconv.Atoi(id); err != nil {
+		return nil, fmt.Errorf("%w: unexpected subject %q", ErrInvalidToken, tc.Subject)
+	}
+
+	return tc, nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, sig []byte) error {
+	h := sha256.Sum256([]byte(signed))
+
+	switch alg {
+	case "RS256":
+		if k, ok := key.(*rsa.PublicKey); ok && rsa.VerifyPKCS1v15(k, crypto.SHA256, h[:], sig) == nil {
+			return nil
+		}
+	case "ES256":
+		if k, ok := key.(*ecdsa.PublicKey); ok && len(sig) == 64 {
+			r := new(big.Int).SetBytes(sig[:32])
+			s := new(big.Int).SetBytes(sig[32:])
+
+			if ecdsa.Verify(k, h[:], r, s) {
+				return nil
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, alg)
+	}
+
+	return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return nil
+}
+
+// stringOrSlice decodes a claim that is either a single string or a list of
+// strings.
+func stringOrSlice(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}, nil
+	}
+
+	var ss []string
+	if err := json.Unmarshal(raw, &ss); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return ss, nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,194 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// signToken returns a token with the given claims, signed with the test key
+// for kid.
+func signToken(t *testing.T, kid string, claims map[string]interface{}) string {
+	alg := map[string]string{"rs": "RS256", "es": "ES256"}[kid]
+
+	seg := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	signed := seg(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + seg(claims)
+	h := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch kid {
+	case "rs":
+		sig, _ = rsa.SignPKCS1v15(rand.Reader, testRSAKey, crypto.SHA256, h[:])
+	case "es":
+		r, s, _ := ecdsa.Sign(rand.Reader, testECKey, h[:])
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func testClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"scp":   []string{"esi-fleets.read_fleet.v1", "esi-wallet.read_character_wallet.v1"},
+		"jti":   "998e12c7-3241-43c5-8355-2c48822e0a1b",
+		"kid":   "JWT-Signature-Key",
+		"sub":   "CHARACTER:EVE:2112625428",
+		"azp":   "client",
+		"name":  "CCP Zoetrope",
+		"owner": "8PmzCeTKb4VFUDrHLc/AeZXDSWM=",
+		"exp":   testNow.Add(time.Minute).Unix(),
+		"iat":   testNow.Add(-time.Minute).Unix(),
+		"iss":   "login.eveonline.com",
+		"aud":   []string{"client", "EVE Online"},
+	}
+}
+
+func testVerifier(url string) *Verifier {
+	v := NewVerifier("client")
+	v.Keys = NewKeySet(url)
+	v.now = func() time.Time { return testNow }
+
+	return v
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	s := newJWKSServer()
+	defer s.Close()
+
+	v := testVerifier(s.URL)
+
+	want := &TokenClaims{
+		CharacterID: 2112625428,
+		Name:        "CCP Zoetrope",
+		Owner:       "8PmzCeTKb4VFUDrHLc/AeZXDSWM=",
+		Scopes:      []string{"esi-fleets.read_fleet.v1", "esi-wallet.read_character_wallet.v1"},
+		Subject:     "CHARACTER:EVE:2112625428",
+		Issuer:      "login.eveonline.com",
+		Audience:    []string{"client", "EVE Online"},
+		IssuedAt:    testNow.Add(-time.Minute),
+		ExpiresAt:   testNow.Add(time.Minute),
+	}
+
+	for _, kid := range []string{"rs", "es"} {
+		claims, err := v.Verify(context.Background(), signToken(t, kid, testClaims()))
+		if err != nil {
+			t.Fatalf("Verify(%s) returned error: %v", kid, err)
+		}
+
+		want.KeyID = kid
+		claims.IssuedAt = claims.IssuedAt.UTC()
+		claims.ExpiresAt = claims.ExpiresAt.UTC()
+
+		if !reflect.DeepEqual(claims, want) {
+			t.Errorf("Verify(%s) returned %+v, want %+v", kid, claims, want)
+		}
+
+		if !claims.HasScope("esi-fleets.read_fleet.v1") || claims.HasScope("esi-fleets.write_fleet.v1") {
+			t.Errorf("HasScope returned wrong results for %v", claims.Scopes)
+		}
+	}
+}
+
+func TestVerifier_Verify_singleScope(t *testing.T) {
+	s := newJWKSServer()
+	defer s.Close()
+
+	c := testClaims()
+	c["scp"] = "publicData"
+	c["iss"] = "https://login.eveonline.com"
+
+	claims, err := testVerifier(s.URL).Verify(context.Background(), signToken(t, "rs", c))
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	if want := []string{"publicData"}; !reflect.DeepEqual(claims.Scopes, want) {
+		t.Errorf("Verify returned scopes %v, want %v", claims.Scopes, want)
+	}
+}
+
+func TestVerifier_Verify_invalid(t *testing.T) {
+	s := newJWKSServer()
+	defer s.Close()
+
+	v := testVerifier(s.URL)
+
+	with := func(k string, val interface{}) string {
+		c := testClaims()
+		c[k] = val
+		return signToken(t, "es", c)
+	}
+
+	valid := signToken(t, "rs", testClaims())
+	tampered := valid[:len(valid)-4] + "AAAA"
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"malformed", "a.b", ErrInvalidToken},
+		{"tampered", tampered, ErrInvalidToken},
+		{"issuer", with("iss", "login.example.com"), ErrInvalidToken},
+		{"audience", with("aud", "EVE Online"), ErrInvalidToken},
+		{"other client", with("aud", []string{"other", "EVE Online"}), ErrInvalidToken},
+		{"subject", with("sub", "CORPORATION:EVE:1"), ErrInvalidToken},
+		{"expired", with("exp", testNow.Add(-time.Minute).Unix()), ErrExpiredToken},
+	}
+
+	for _, tt := range tests {
+		_, err := v.Verify(context.Background(), tt.token)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: Verify returned error %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestVerifier_Verify_leeway(t *testing.T) {
+	s := newJWKSServer()
+	defer s.Close()
+
+	c := testClaims()
+	c["exp"] = testNow.Add(-2 * time.Second).Unix()
+
+	if _, err := testVerifier(s.URL).Verify(context.Background(), signToken(t, "rs", c)); err != nil {
+		t.Errorf("Verify returned error: %v", err)
+	}
+}
+
+func TestVerifier_Verify_wrongKeyType(t *testing.T) {
+	s := newJWKSServer()
+	defer s.Close()
+
+	// an RS256 token claiming to be signed with the EC key
+	token := signToken(t, "rs", testClaims())
+	b, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "es"})
+
+	token = base64.RawURLEncoding.EncodeToString(b) + token[strings.Index(token, "."):]
+
+	if _, err := testVerifier(s.URL).Verify(context.Background(), token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify returned error %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,13 @@
+package sso
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// challenge returns the S256 PKCE code challenge of verifier, as defined in
+// RFC 7636.
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,13 @@
+package sso
+
+import "testing"
+
+func TestChallenge(t *testing.T) {
+	// RFC 7636, appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	if got := challenge(verifier); got != want {
+		t.Errorf("challenge(%q) = %q, want %q", verifier, got, want)
+	}
+}
@@ -0,0 +1,177 @@
+package sso
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"corpus.space/esi"
+)
+
+// A Registry holds the tokens of many characters, refreshing them as needed
+// and persisting them in a TokenStore. Together with esi.WithCharacter it lets
+// a single esi.Client act on behalf of any of the characters:
+//
+//	registry := sso.NewRegistry(config, store)
+//	api := esi.NewClient(registry.Client(nil))
+//
+//	ctx = esi.WithCharacter(ctx, cid)
+//	balance, _, err := api.Wallet.GetCharacterBalance(ctx, cid)
+//
+// A Registry is safe for concurrent use.
+type Registry struct {
+	config *Config
+	store  TokenStore
+
+	mu     sync.Mutex
+	tokens map[int]*registryEntry
+}
+
+type registryEntry struct {
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// NewRegistry returns a Registry that refreshes tokens with config and
+// persists them in store.
+func NewRegistry(config *Config, store TokenStore) *Registry {
+	return &Registry{
+		config: config,
+		store:  store,
+		tokens: make(map[int]*registryEntry),
+	}
+}
+
+func (r *Registry) entry(cid int) *registryEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.tokens[cid]
+	if !ok {
+		e = new(registryEntry)
+		r.tokens[cid] = e
+	}
+
+	return e
+}
+
+// Add adds the token of a character to the registry, replacing any previous
+// token of the character.
+func (r *Registry) Add(ctx context.Context, cid int, t *oauth2.Token) error {
+	e := r.entry(cid)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := r.store.Save(ctx, cid, t); err != nil {
+		return err
+	}
+
+	e.token = t
+
+	return nil
+}
+
+// Remove removes the token of a character from the registry. The token is not
+// revoked; use Config.Revoke for that.
+func (r *Registry) Remove(ctx context.Context, cid int) error {
+	e := r.entry(cid)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := r.store.Delete(ctx, cid); err != nil {
+		return err
+	}
+
+	e.token = nil
+
+	return nil
+}
+
+// Token returns a valid token of a character. Expired tokens are refreshed
+// and the refreshed token is saved in the store. If the registry holds no
+// token for the character, an error matching ErrNoToken is returned.
+func (r *Registry) Token(ctx context.Context, cid int) (*oauth2.Token, error) {
+	e := r.entry(cid)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.token == nil {
+		t, err := r.store.Load(ctx, cid)
+		if err != nil {
+			return nil, err
+		}
+
+		e.token = t
+	}
+
+	if e.token.Valid() {
+		return e.token, nil
+	}
+
+	t, err := r.config.TokenSource(ctx, e.token).Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.store.Save(ctx, cid, t); err != nil {
+		return nil, err
+	}
+
+	e.token = t
+
+	return t, nil
+}
+
+// Client returns an HTTP client that authorizes each request as the
+// character set in its context with esi.WithCharacter. Requests without a
+// character are sent without authorization. If base is nil,
+// http.DefaultTransport is used.
+func (r *Registry) Client(base http.RoundTripper) *http.Client {
+	return &http.Client{Transport: &Transport{Registry: r, Base: base}}
+}
+
+// Transport is an http.RoundTripper that authorizes requests with the token
+// of the character set in their context with esi.WithCharacter.
+type Transport struct {
+	Registry *Registry
+
+	// Base is the transport used to make requests. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	cid, ok := esi.CharacterFromContext(req.Context())
+	if !ok {
+		return base.RoundTrip(req)
+	}
+
+	token, err := t.Registry.Token(req.Context(), cid)
+	if err != nil {
+		closeBody(req)
+		return nil, err
+	}
+
+	// a RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+	token.SetAuthHeader(req)
+
+	return base.RoundTrip(req)
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
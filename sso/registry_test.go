@@ -0,0 +1,136 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"corpus.space/esi"
+)
+
+func TestRegistry_Token(t *testing.T) {
+	s := newSSOServer(t)
+	defer s.Close()
+
+	store := NewMemoryTokenStore()
+	r := NewRegistry(s.config(""), store)
+
+	valid := &oauth2.Token{AccessToken: "access1", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)}
+	if err := r.Add(context.Background(), 42, valid); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	token, err := r.Token(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+
+	if token.AccessToken != "access1" {
+		t.Errorf("Token returned %q, want access1", token.AccessToken)
+	}
+
+	if _, err := r.Token(context.Background(), 43); err != ErrNoToken {
+		t.Errorf("Token returned error %v, want ErrNoToken", err)
+	}
+}
+
+func TestRegistry_Token_refresh(t *testing.T) {
+	s := newSSOServer(t)
+	defer s.Close()
+
+	store := NewMemoryTokenStore()
+	store.Save(context.Background(), 42, &oauth2.Token{
+		AccessToken:  "access1",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+
+	r := NewRegistry(s.config(""), store)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			token, err := r.Token(context.Background(), 42)
+			if err != nil {
+				t.Errorf("Token returned error: %v", err)
+				return
+			}
+
+			if token.AccessToken != "access2" {
+				t.Errorf("Token returned %q, want access2", token.AccessToken)
+			}
+		}()
+	}
+	wg.Wait()
+
+	saved, _ := store.Load(context.Background(), 42)
+	if saved.AccessToken != "access2" || saved.RefreshToken != "refresh" {
+		t.Errorf("store holds %+v after refresh", saved)
+	}
+}
+
+func TestRegistry_Remove(t *testing.T) {
+	store := NewMemoryTokenStore()
+	r := NewRegistry(NewConfig("client", "", ""), store)
+
+	r.Add(context.Background(), 42, &oauth2.Token{AccessToken: "access"})
+	if err := r.Remove(context.Background(), 42); err != nil {
+		t.Fatalf("Remove returned error: %v", err)
+	}
+
+	if _, err := r.Token(context.Background(), 42); err != ErrNoToken {
+		t.Errorf("Token returned error %v, want ErrNoToken", err)
+	}
+
+	if _, err := store.Load(context.Background(), 42); err != ErrNoToken {
+		t.Errorf("store Load returned error %v, want ErrNoToken", err)
+	}
+}
+
+func TestRegistry_Client(t *testing.T) {
+	var auth []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Write([]byte("1.5"))
+	}))
+	defer api.Close()
+
+	r := NewRegistry(NewConfig("client", "", ""), NewMemoryTokenStore())
+	for cid, access := range map[int]string{42: "access42", 43: "access43"} {
+		r.Add(context.Background(), cid, &oauth2.Token{AccessToken: access, Expiry: time.Now().Add(time.Hour)})
+	}
+
+	client := esi.NewClient(r.Client(nil))
+	client.BaseURL.Host = api.Listener.Addr().String()
+	client.BaseURL.Scheme = "http"
+
+	ctx := context.Background()
+	client.Wallet.GetCharacterBalance(esi.WithCharacter(ctx, 42), 42)
+	client.Wallet.GetCharacterBalance(esi.WithCharacter(ctx, 43), 43)
+	client.Alliances.List(ctx)
+
+	want := []string{"Bearer access42", "Bearer access43", ""}
+	if len(auth) != len(want) {
+		t.Fatalf("server received %d requests, want %d", len(auth), len(want))
+	}
+
+	for i := range want {
+		if auth[i] != want[i] {
+			t.Errorf("request %d had Authorization %q, want %q", i, auth[i], want[i])
+		}
+	}
+
+	_, _, err := client.Wallet.GetCharacterBalance(esi.WithCharacter(ctx, 44), 44)
+	if !errors.Is(err, ErrNoToken) {
+		t.Errorf("GetCharacterBalance returned error %v, want ErrNoToken", err)
+	}
+}
@@ -0,0 +1,240 @@
+// Package sso implements the EVE Online single sign-on (SSO) v2 OAuth 2.0
+// flow.
+//
+// Web applications with a client secret and native applications using PKCE
+// are both supported. A login starts with Config.Begin, which returns the URL
+// to send the user to. When the user is redirected back, the code in the
+// callback is exchanged for a token with Config.Complete:
+//
+//	config := sso.NewConfig(clientID, "", "http://localhost:8080/callback", "esi-fleets.read_fleet.v1")
+//
+//	login, err := config.Begin()
+//	if err != nil {
+//		...
+//	}
+//
+//	// redirect the user to login.URL and wait for the callback request r
+//
+//	token, err := config.CompleteRequest(ctx, login, r)
+//	if err != nil {
+//		...
+//	}
+//
+//	client := esi.NewClient(config.Client(ctx, token))
+//
+// Tokens are refreshed automatically by the returned client.
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// EVE SSO v2 endpoints.
+const (
+	AuthURL   = "https://login.eveonline.com/v2/oauth/authorize"
+	TokenURL  = "https://login.eveonline.com/v2/oauth/token"
+	RevokeURL = "https://login.eveonline.com/v2/oauth/revoke"
+)
+
+// Endpoint is the EVE SSO v2 OAuth 2.0 endpoint.
+var Endpoint = oauth2.Endpoint{
+	AuthURL:  AuthURL,
+	TokenURL: TokenURL,
+}
+
+var (
+	// ErrStateMismatch is returned if the state of a callback does not match
+	// the state of the login.
+	ErrStateMismatch = errors.New("sso: state mismatch")
+
+	// ErrNoCode is returned if a callback has no authorization code.
+	ErrNoCode = errors.New("sso: no authorization code in callback")
+)
+
+// A CallbackError is returned if the SSO redirected back with an error, for
+// example because the user declined the login.
+type CallbackError struct {
+	Code        string
+	Description string
+}
+
+func (e *CallbackError) Error() string {
+	if e.Description == "" {
+		return "sso: " + e.Code
+	}
+
+	return fmt.Sprintf("sso: %s: %s", e.Code, e.Description)
+}
+
+// Config is the configuration of an SSO application.
+type Config struct {
+	oauth2.Config
+
+	// RevokeURL is the URL used to revoke refresh tokens.
+	RevokeURL string
+}
+
+// NewConfig returns the configuration of an SSO application using the EVE
+// SSO v2 endpoints. Native applications without a client secret should pass
+// an empty clientSecret; the client ID is then sent in the request body and
+// PKCE protects the authorization code.
+func NewConfig(clientID, clientSecret, redirectURL string, scopes ...string) *Config {
+	c := &Config{
+		Config: oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       scopes,
+			Endpoint:     Endpoint,
+		},
+		RevokeURL: RevokeURL,
+	}
+
+	if clientSecret == "" {
+		c.Endpoint.AuthStyle = oauth2.AuthStyleInParams
+	}
+
+	return c
+}
+
+// A Login is a single login attempt. Its fields must be kept, for example in
+// the user's session, until the callback is received.
+type Login struct {
+	// URL is the SSO URL to send the user to.
+	URL string
+
+	// State is the random state the callback must carry.
+	State string
+
+	// Verifier is the PKCE code verifier.
+	Verifier string
+}
+
+// Begin starts a new login.
+func (c *Config) Begin() (*Login, error) {
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	url := c.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("code_challenge", challenge(verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+
+	return &Login{URL: url, State: state, Verifier: verifier}, nil
+}
+
+// Complete verifies the state of a callback and exchanges its authorization
+// code for a token.
+func (c *Config) Complete(ctx context.Context, login *Login, state, code string) (*oauth2.Token, error) {
+	if login == nil || subtle.ConstantTimeCompare([]byte(state), []byte(login.State)) != 1 {
+		return nil, ErrStateMismatch
+	}
+
+	if code == "" {
+		return nil, ErrNoCode
+	}
+
+	return c.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", login.Verifier))
+}
+
+// CompleteRequest completes a login from the callback request r.
+func (c *Config) CompleteRequest(ctx context.Context, login *Login, r *http.Request) (*oauth2.Token, error) {
+	q := r.URL.Query()
+
+	if code := q.Get("error"); code != "" {
+		return nil, &CallbackError{Code: code, Description: q.Get("error_description")}
+	}
+
+	return c.Complete(ctx, login, q.Get("state"), q.Get("code"))
+}
+
+// TokenSource returns a token source that returns t until it expires, and
+// then refreshes it.
+func (c *Config) TokenSource(ctx context.Context, t *oauth2.Token) oauth2.TokenSource {
+	return c.Config.TokenSource(ctx, t)
+}
+
+// Client returns an HTTP client that authorizes requests with t, refreshing
+// it as needed. The client can be passed to esi.NewClient.
+func (c *Config) Client(ctx context.Context, t *oauth2.Token) *http.Client {
+	return oauth2.NewClient(ctx, c.TokenSource(ctx, t))
+}
+
+// Revoke revokes the refresh token of t, which also invalidates its access
+// tokens.
+func (c *Config) Revoke(ctx context.Context, t *oauth2.Token) error {
+	if t == nil || t.RefreshToken == "" {
+		return errors.New("sso: no refresh token to revoke")
+	}
+
+	v := url.Values{
+		"token":           {t.RefreshToken},
+		"token_type_hint": {"refresh_token"},
+	}
+
+	if c.ClientSecret == "" {
+		v.Set("client_id", c.ClientID)
+	}
+
+	req, err := http.NewRequest("POST", c.RevokeURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if c.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	}
+
+	resp, err := contextClient(ctx).Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("sso: revoke failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// contextClient returns the HTTP client set in ctx with oauth2.HTTPClient,
+// or http.DefaultClient.
+func contextClient(ctx context.Context) *http.Client {
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && c != nil {
+		return c
+	}
+
+	return http.DefaultClient
+}
+
+// randomString returns n random bytes, base64url encoded.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
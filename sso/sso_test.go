@@ -0,0 +1,289 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// ssoServer is a minimal stand-in for the EVE SSO.
+type ssoServer struct {
+	*httptest.Server
+
+	t *testing.T
+
+	// challenge received on the authorize endpoint
+	challenge string
+
+	revoked []string
+}
+
+func newSSOServer(t *testing.T) *ssoServer {
+	s := &ssoServer{t: t}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/oauth/authorize", s.authorize)
+	mux.HandleFunc("/v2/oauth/token", s.token)
+	mux.HandleFunc("/v2/oauth/revoke", s.revoke)
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+func (s *ssoServer) config(secret string) *Config {
+	c := NewConfig("client", secret, "http://localhost/callback", "esi-fleets.read_fleet.v1")
+	c.Endpoint.AuthURL = s.URL + "/v2/oauth/authorize"
+	c.Endpoint.TokenURL = s.URL + "/v2/oauth/token"
+	c.RevokeURL = s.URL + "/v2/oauth/revoke"
+
+	return c
+}
+
+// authorize logs in immediately and redirects back with a code.
+func (s *ssoServer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("code_challenge_method") != "S256" {
+		s.t.Errorf("code_challenge_method = %q, want S256", q.Get("code_challenge_method"))
+	}
+
+	s.challenge = q.Get("code_challenge")
+
+	u, _ := url.Parse(q.Get("redirect_uri"))
+	u.RawQuery = url.Values{"code": {"authcode"}, "state": {q.Get("state")}}.Encode()
+
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func (s *ssoServer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.t.Fatalf("unexpected error: %v", err)
+	}
+
+	if id, _, ok := r.BasicAuth(); !ok && r.PostForm.Get("client_id") != "client" || ok && id != "client" {
+		http.Error(w, `{"error": "invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		if r.PostForm.Get("code") != "authcode" || challenge(r.PostForm.Get("code_verifier")) != s.challenge {
+			http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "access1", "token_type": "Bearer", "expires_in": 1199, "refresh_token": "refresh"}`)
+	case "refresh_token":
+		if r.PostForm.Get("refresh_token") != "refresh" {
+			http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "access2", "token_type": "Bearer", "expires_in": 1199, "refresh_token": "refresh"}`)
+	default:
+		http.Error(w, `{"error": "unsupported_grant_type"}`, http.StatusBadRequest)
+	}
+}
+
+func (s *ssoServer) revoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		s.t.Errorf("Request method: %v, want POST", r.Method)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.PostForm.Get("token_type_hint") != "refresh_token" {
+		http.Error(w, "bad hint", http.StatusBadRequest)
+		return
+	}
+
+	s.revoked = append(s.revoked, r.PostForm.Get("token"))
+}
+
+// login runs the authorize step and returns the callback request.
+func login(t *testing.T, l *Login) *http.Request {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(l.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	return httptest.NewRequest("GET", resp.Header.Get("Location"), nil)
+}
+
+func TestConfig_flow(t *testing.T) {
+	for _, secret := range []string{"", "secret"} {
+		s := newSSOServer(t)
+		c := s.config(secret)
+
+		l, err := c.Begin()
+		if err != nil {
+			t.Fatalf("Begin returned error: %v", err)
+		}
+
+		token, err := c.CompleteRequest(context.Background(), l, login(t, l))
+		if err != nil {
+			t.Fatalf("CompleteRequest returned error: %v", err)
+		}
+
+		if token.AccessToken != "access1" || token.RefreshToken != "refresh" {
+			t.Errorf("CompleteRequest returned token %+v", token)
+		}
+
+		s.Close()
+	}
+}
+
+func TestConfig_Begin(t *testing.T) {
+	c := NewConfig("client", "", "http://localhost/callback", "a", "b")
+
+	l1, err := c.Begin()
+	if err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+
+	l2, _ := c.Begin()
+	if l1.State == l2.State || l1.Verifier == l2.Verifier {
+		t.Errorf("Begin returned the same state or verifier twice")
+	}
+
+	u, _ := url.Parse(l1.URL)
+	q := u.Query()
+
+	want := map[string]string{
+		"client_id":             "client",
+		"redirect_uri":          "http://localhost/callback",
+		"response_type":         "code",
+		"scope":                 "a b",
+		"state":                 l1.State,
+		"code_challenge":        challenge(l1.Verifier),
+		"code_challenge_method": "S256",
+	}
+	for k, v := range want {
+		if got := q.Get(k); got != v {
+			t.Errorf("URL parameter %s = %q, want %q", k, got, v)
+		}
+	}
+
+	if got := u.Scheme + "://" + u.Host + u.Path; got != AuthURL {
+		t.Errorf("URL = %q, want %q", got, AuthURL)
+	}
+}
+
+func TestConfig_Complete_stateMismatch(t *testing.T) {
+	c := NewConfig("client", "", "http://localhost/callback")
+
+	l, _ := c.Begin()
+	if _, err := c.Complete(context.Background(), l, "forged", "authcode"); err != ErrStateMismatch {
+		t.Errorf("Complete returned error %v, want ErrStateMismatch", err)
+	}
+
+	if _, err := c.Complete(context.Background(), nil, "", "authcode"); err != ErrStateMismatch {
+		t.Errorf("Complete returned error %v, want ErrStateMismatch", err)
+	}
+
+	if _, err := c.Complete(context.Background(), l, l.State, ""); err != ErrNoCode {
+		t.Errorf("Complete returned error %v, want ErrNoCode", err)
+	}
+}
+
+func TestConfig_Complete_badVerifier(t *testing.T) {
+	s := newSSOServer(t)
+	defer s.Close()
+
+	c := s.config("")
+
+	l, _ := c.Begin()
+	r := login(t, l)
+	l.Verifier = "tampered"
+
+	if _, err := c.CompleteRequest(context.Background(), l, r); err == nil {
+		t.Errorf("CompleteRequest returned no error")
+	}
+}
+
+func TestConfig_CompleteRequest_callbackError(t *testing.T) {
+	c := NewConfig("client", "", "http://localhost/callback")
+	l, _ := c.Begin()
+
+	r := httptest.NewRequest("GET", "/callback?error=access_denied&error_description=declined", nil)
+
+	_, err := c.CompleteRequest(context.Background(), l, r)
+
+	var cerr *CallbackError
+	if !errors.As(err, &cerr) || cerr.Code != "access_denied" || cerr.Description != "declined" {
+		t.Errorf("CompleteRequest returned error %v, want *CallbackError", err)
+	}
+
+	if got, want := err.Error(), "sso: access_denied: declined"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestConfig_Client_refresh(t *testing.T) {
+	s := newSSOServer(t)
+	defer s.Close()
+
+	var auth string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer api.Close()
+
+	c := s.config("")
+	expired := &oauth2.Token{
+		AccessToken:  "access1",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Minute),
+	}
+
+	resp, err := c.Client(context.Background(), expired).Get(api.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if want := "Bearer access2"; auth != want {
+		t.Errorf("Authorization = %q, want %q", auth, want)
+	}
+}
+
+func TestConfig_Revoke(t *testing.T) {
+	s := newSSOServer(t)
+	defer s.Close()
+
+	c := s.config("")
+	if err := c.Revoke(context.Background(), &oauth2.Token{RefreshToken: "refresh"}); err != nil {
+		t.Fatalf("Revoke returned error: %v", err)
+	}
+
+	if len(s.revoked) != 1 || s.revoked[0] != "refresh" {
+		t.Errorf("revoked %v, want [refresh]", s.revoked)
+	}
+
+	if err := c.Revoke(context.Background(), &oauth2.Token{}); err == nil {
+		t.Errorf("Revoke without refresh token returned no error")
+	}
+
+	c.RevokeURL = s.URL + "/missing"
+	if err := c.Revoke(context.Background(), &oauth2.Token{RefreshToken: "refresh"}); err == nil {
+		t.Errorf("Revoke returned no error on 404")
+	}
+}
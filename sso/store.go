@@ -0,0 +1,195 @@
+package sso
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrNoToken is returned by a TokenStore if it holds no token for a
+// character.
+var ErrNoToken = errors.New("sso: no token for character")
+
+// A TokenStore persists the tokens of characters. Implementations must be
+// safe for concurrent use.
+type TokenStore interface {
+	// Load returns the token of a character, or ErrNoToken.
+	Load(ctx context.Context, cid int) (*oauth2.Token, error)
+
+	// Save stores the token of a character, replacing any previous token.
+	Save(ctx context.Context, cid int, t *oauth2.Token) error
+
+	// Delete removes the token of a character. Deleting a token that does
+	// not exist is not an error.
+	Delete(ctx context.Context, cid int) error
+}
+
+// A MemoryTokenStore is a TokenStore that keeps tokens in memory.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[int]oauth2.Token
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[int]oauth2.Token)}
+}
+
+// Load implements TokenStore.
+func (s *MemoryTokenStore) Load(ctx context.Context, cid int) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tokens[cid]
+	if !ok {
+		return nil, ErrNoToken
+	}
+
+	return &t, nil
+}
+
+// Save implements TokenStore.
+func (s *MemoryTokenStore) Save(ctx context.Context, cid int, t *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[cid] = *t
+
+	return nil
+}
+
+// Delete implements TokenStore.
+func (s *MemoryTokenStore) Delete(ctx context.Context, cid int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tokens, cid)
+
+	return nil
+}
+
+// A FileTokenStore is a TokenStore that keeps each token in its own file in a
+// directory, encrypted with AES-GCM. The character ID is authenticated along
+// with the token, so files cannot be swapped between characters.
+type FileTokenStore struct {
+	dir  string
+	aead cipher.AEAD
+
+	mu sync.Mutex
+}
+
+// NewFileTokenStore returns a FileTokenStore keeping tokens in dir, which is
+// created if needed. The key must be 16, 24 or 32 bytes long, selecting
+// AES-128, AES-192 or AES-256.
+func NewFileTokenStore(dir string, key []byte) (*FileTokenStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FileTokenStore{dir: dir, aead: aead}, nil
+}
+
+func (s *FileTokenStore) path(cid int) string {
+	return filepath.Join(s.dir, strconv.Itoa(cid)+".token")
+}
+
+// Load implements TokenStore.
+func (s *FileTokenStore) Load(ctx context.Context, cid int) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := ioutil.ReadFile(s.path(cid))
+	if os.IsNotExist(err) {
+		return nil, ErrNoToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	n := s.aead.NonceSize()
+	if len(data) < n {
+		return nil, fmt.Errorf("sso: token file for character %d is corrupt", cid)
+	}
+
+	plain, err := s.aead.Open(nil, data[:n], data[n:], []byte(strconv.Itoa(cid)))
+	if err != nil {
+		return nil, fmt.Errorf("sso: decrypting token of character %d: %v", cid, err)
+	}
+
+	t := new(oauth2.Token)
+	if err := json.Unmarshal(plain, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Save implements TokenStore. The file is replaced atomically.
+func (s *FileTokenStore) Save(ctx context.Context, cid int, t *oauth2.Token) error {
+	plain, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	data := s.aead.Seal(nonce, nonce, plain, []byte(strconv.Itoa(cid)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := ioutil.TempFile(s.dir, ".token")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), s.path(cid))
+}
+
+// Delete implements TokenStore.
+func (s *FileTokenStore) Delete(ctx context.Context, cid int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(cid)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
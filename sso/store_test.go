@@ -0,0 +1,125 @@
+package sso
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+var testToken = &oauth2.Token{
+	AccessToken:  "access",
+	TokenType:    "Bearer",
+	RefreshToken: "refresh",
+	Expiry:       time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func testTokenStore(t *testing.T, s TokenStore) {
+	ctx := context.Background()
+
+	if _, err := s.Load(ctx, 42); err != ErrNoToken {
+		t.Errorf("Load returned error %v, want ErrNoToken", err)
+	}
+
+	if err := s.Save(ctx, 42, testToken); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	got, err := s.Load(ctx, 42)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, testToken) {
+		t.Errorf("Load returned %+v, want %+v", got, testToken)
+	}
+
+	if err := s.Delete(ctx, 42); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	if _, err := s.Load(ctx, 42); err != ErrNoToken {
+		t.Errorf("Load after Delete returned error %v, want ErrNoToken", err)
+	}
+
+	if err := s.Delete(ctx, 42); err != nil {
+		t.Errorf("Delete of missing token returned error: %v", err)
+	}
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	testTokenStore(t, NewMemoryTokenStore())
+}
+
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "sso")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestFileTokenStore(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	s, err := NewFileTokenStore(filepath.Join(dir, "tokens"), testKey)
+	if err != nil {
+		t.Fatalf("NewFileTokenStore returned error: %v", err)
+	}
+
+	testTokenStore(t, s)
+}
+
+func TestFileTokenStore_encrypted(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	s, _ := NewFileTokenStore(dir, testKey)
+	s.Save(context.Background(), 42, testToken)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "42.token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, secret := range []string{testToken.AccessToken, testToken.RefreshToken} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("token file contains %q in plain text", secret)
+		}
+	}
+
+	info, _ := os.Stat(filepath.Join(dir, "42.token"))
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		t.Errorf("token file has permissions %v", perm)
+	}
+
+	other, _ := NewFileTokenStore(dir, []byte("fedcba9876543210fedcba9876543210"))
+	if _, err := other.Load(context.Background(), 42); err == nil {
+		t.Errorf("Load with wrong key returned no error")
+	}
+
+	// a token file moved to another character fails authentication
+	os.Rename(filepath.Join(dir, "42.token"), filepath.Join(dir, "43.token"))
+	if _, err := s.Load(context.Background(), 43); err == nil {
+		t.Errorf("Load of swapped token file returned no error")
+	}
+}
+
+func TestNewFileTokenStore_badKey(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	if _, err := NewFileTokenStore(dir, []byte("short")); err == nil {
+		t.Errorf("NewFileTokenStore returned no error")
+	}
+}
@@ -113,6 +113,8 @@ func TestString(t *testing.T) {
 		out string
 	}{
 		{CharacterPublicInfo{Name: String("pascal")}, `esi.CharacterPublicInfo{Name:"pascal"}`},
+		{AlliancePublicInfo{Ticker: String("<C C P>")}, `esi.AlliancePublicInfo{Ticker:"<C C P>"}`},
+		{MedalGraphic{Graphic: String("caldari.1_1")}, `esi.MedalGraphic{Graphic:"caldari.1_1"}`},
 	}
 
 	for i, tt := range tests {
@@ -2,6 +2,8 @@ package esi
 
 import "time"
 
+const dateLayout = "2006-01-02"
+
 // Timestamp is a time.Time
 type Timestamp struct {
 	time.Time
@@ -10,3 +12,13 @@ type Timestamp struct {
 func (t Timestamp) String() string {
 	return t.Time.String()
 }
+
+// Date is a calendar date, such as the dates of market history entries. Its
+// location is always UTC.
+type Date struct {
+	time.Time
+}
+
+func (d Date) String() string {
+	return d.Format(dateLayout)
+}
@@ -27,3 +27,26 @@ func (t *Timestamp) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements the json.Marshaller interface.
+func (d Date) MarshalJSON() ([]byte, error) {
+	s := fmt.Sprintf(`"%s"`, d.Time.Format(dateLayout))
+
+	return []byte(s), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	s := string(b)
+
+	s = s[1 : len(s)-1]
+
+	parsed, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return err
+	}
+
+	*d = Date{parsed}
+
+	return nil
+}
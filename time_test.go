@@ -50,3 +50,34 @@ func TestTimestamp_UnmarshalJSON_invalidTimestampFormat(t *testing.T) {
 		t.Fatal("expected *time.ParseError")
 	}
 }
+
+func TestDate_MarshalJSON(t *testing.T) {
+	d := Date{time.Date(2018, 3, 14, 0, 0, 0, 0, time.UTC)}
+	buf, _ := json.Marshal(d)
+	if string(buf) != `"2018-03-14"` {
+		t.Fatalf("expected %q; got %q", `"2018-03-14"`, string(buf))
+	}
+}
+
+func TestDate_UnmarshalJSON(t *testing.T) {
+	var d Date
+	if err := json.Unmarshal([]byte(`"2018-03-14"`), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := time.Date(2018, 3, 14, 0, 0, 0, 0, time.UTC); !d.Time.Equal(want) {
+		t.Fatalf("want %q; got %q", want, d)
+	}
+}
+
+func TestDate_UnmarshalJSON_invalidDateFormat(t *testing.T) {
+	var d Date
+	err := json.Unmarshal([]byte(`"2018-03-14T00:00:00Z"`), &d)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, ok := err.(*time.ParseError); !ok {
+		t.Fatal("expected *time.ParseError")
+	}
+}
@@ -0,0 +1,1002 @@
+package esi
+
+import "context"
+
+// UniverseEndpoint handles communication with the universe related methods of
+// the ESI API.
+type UniverseEndpoint endpoint
+
+// ListSystems returns the IDs of all solar systems.
+func (e *UniverseEndpoint) ListSystems(ctx context.Context) ([]int, *Response, error) {
+	u := e.api.route("get_universe_systems")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// SystemPlanet holds a planet of a solar system and its moons and asteroid
+// belts.
+type SystemPlanet struct {
+	AsteroidBelts []int `json:"asteroid_belts,omitempty"`
+	Moons         []int `json:"moons,omitempty"`
+	PlanetID      *int  `json:"planet_id,omitempty"`
+}
+
+// System holds details of a solar system.
+type System struct {
+	ConstellationID *int            `json:"constellation_id,omitempty"`
+	Name            *string         `json:"name,omitempty"`
+	Planets         []*SystemPlanet `json:"planets,omitempty"`
+	Position        *Position       `json:"position,omitempty"`
+	SecurityClass   *string         `json:"security_class,omitempty"`
+	SecurityStatus  *float64        `json:"security_status,omitempty"`
+	StarID          *int            `json:"star_id,omitempty"`
+	Stargates       []int           `json:"stargates,omitempty"`
+	Stations        []int           `json:"stations,omitempty"`
+	SystemID        *int            `json:"system_id,omitempty"`
+}
+
+func (s System) String() string {
+	return Stringify(s)
+}
+
+// GetSystem returns details of a solar system.
+func (e *UniverseEndpoint) GetSystem(ctx context.Context, sid int, opt *I18NOptions) (*System, *Response, error) {
+	u := e.api.route("get_universe_systems_system_id", sid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	system := new(System)
+	resp, err := e.api.Do(ctx, req, system)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return system, resp, nil
+}
+
+// ListConstellations returns the IDs of all constellations.
+func (e *UniverseEndpoint) ListConstellations(ctx context.Context) ([]int, *Response, error) {
+	u := e.api.route("get_universe_constellations")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// Constellation holds details of a constellation.
+type Constellation struct {
+	ConstellationID *int      `json:"constellation_id,omitempty"`
+	Name            *string   `json:"name,omitempty"`
+	Position        *Position `json:"position,omitempty"`
+	RegionID        *int      `json:"region_id,omitempty"`
+	Systems         []int     `json:"systems,omitempty"`
+}
+
+func (s Constellation) String() string {
+	return Stringify(s)
+}
+
+// GetConstellation returns details of a constellation.
+func (e *UniverseEndpoint) GetConstellation(ctx context.Context, cid int, opt *I18NOptions) (*Constellation, *Response, error) {
+	u := e.api.route("get_universe_constellations_constellation_id", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	constellation := new(Constellation)
+	resp, err := e.api.Do(ctx, req, constellation)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return constellation, resp, nil
+}
+
+// ListRegions returns the IDs of all regions.
+func (e *UniverseEndpoint) ListRegions(ctx context.Context) ([]int, *Response, error) {
+	u := e.api.route("get_universe_regions")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// Region holds details of a region.
+type Region struct {
+	Constellations []int   `json:"constellations,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	Name           *string `json:"name,omitempty"`
+	RegionID       *int    `json:"region_id,omitempty"`
+}
+
+func (s Region) String() string {
+	return Stringify(s)
+}
+
+// GetRegion returns details of a region.
+func (e *UniverseEndpoint) GetRegion(ctx context.Context, rid int, opt *I18NOptions) (*Region, *Response, error) {
+	u := e.api.route("get_universe_regions_region_id", rid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	region := new(Region)
+	resp, err := e.api.Do(ctx, req, region)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return region, resp, nil
+}
+
+// StargateDestination holds the stargate on the other side of a stargate.
+type StargateDestination struct {
+	StargateID *int `json:"stargate_id,omitempty"`
+	SystemID   *int `json:"system_id,omitempty"`
+}
+
+// Stargate holds details of a stargate.
+type Stargate struct {
+	Destination *StargateDestination `json:"destination,omitempty"`
+	Name        *string              `json:"name,omitempty"`
+	Position    *Position            `json:"position,omitempty"`
+	StargateID  *int                 `json:"stargate_id,omitempty"`
+	SystemID    *int                 `json:"system_id,omitempty"`
+	TypeID      *int                 `json:"type_id,omitempty"`
+}
+
+func (s Stargate) String() string {
+	return Stringify(s)
+}
+
+// GetStargate returns details of a stargate.
+func (e *UniverseEndpoint) GetStargate(ctx context.Context, sid int) (*Stargate, *Response, error) {
+	u := e.api.route("get_universe_stargates_stargate_id", sid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stargate := new(Stargate)
+	resp, err := e.api.Do(ctx, req, stargate)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return stargate, resp, nil
+}
+
+// Station holds details of an NPC station.
+type Station struct {
+	MaxDockableShipVolume    *float64  `json:"max_dockable_ship_volume,omitempty"`
+	Name                     *string   `json:"name,omitempty"`
+	OfficeRentalCost         *float64  `json:"office_rental_cost,omitempty"`
+	Owner                    *int      `json:"owner,omitempty"`
+	Position                 *Position `json:"position,omitempty"`
+	RaceID                   *int      `json:"race_id,omitempty"`
+	ReprocessingEfficiency   *float64  `json:"reprocessing_efficiency,omitempty"`
+	ReprocessingStationsTake *float64  `json:"reprocessing_stations_take,omitempty"`
+	Services                 []string  `json:"services,omitempty"`
+	StationID                *int      `json:"station_id,omitempty"`
+	SystemID                 *int      `json:"system_id,omitempty"`
+	TypeID                   *int      `json:"type_id,omitempty"`
+}
+
+func (s Station) String() string {
+	return Stringify(s)
+}
+
+// GetStation returns details of an NPC station.
+func (e *UniverseEndpoint) GetStation(ctx context.Context, sid int) (*Station, *Response, error) {
+	u := e.api.route("get_universe_stations_station_id", sid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	station := new(Station)
+	resp, err := e.api.Do(ctx, req, station)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return station, resp, nil
+}
+
+// StructuresOptions specifies the optional parameters to the
+// UniverseEndpoint.ListStructures method.
+type StructuresOptions struct {
+	// Filter only returns structures with the given service; "market" or
+	// "manufacturing_basic".
+	Filter string `url:"filter,omitempty"`
+}
+
+// ListStructures returns the IDs of all public structures.
+func (e *UniverseEndpoint) ListStructures(ctx context.Context, opt *StructuresOptions) ([]int64, *Response, error) {
+	u := e.api.route("get_universe_structures")
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int64
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// Structure holds details of an Upwell structure.
+type Structure struct {
+	Name          *string   `json:"name,omitempty"`
+	OwnerID       *int      `json:"owner_id,omitempty"`
+	Position      *Position `json:"position,omitempty"`
+	SolarSystemID *int      `json:"solar_system_id,omitempty"`
+	TypeID        *int      `json:"type_id,omitempty"`
+}
+
+func (s Structure) String() string {
+	return Stringify(s)
+}
+
+// GetStructure returns details of a structure. The character must have
+// docking access to the structure.
+func (e *UniverseEndpoint) GetStructure(ctx context.Context, sid int64) (*Structure, *Response, error) {
+	u := e.api.route("get_universe_structures_structure_id", sid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	structure := new(Structure)
+	resp, err := e.api.Do(ctx, req, structure)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return structure, resp, nil
+}
+
+// Planet holds details of a planet.
+type Planet struct {
+	Name     *string   `json:"name,omitempty"`
+	PlanetID *int      `json:"planet_id,omitempty"`
+	Position *Position `json:"position,omitempty"`
+	SystemID *int      `json:"system_id,omitempty"`
+	TypeID   *int      `json:"type_id,omitempty"`
+}
+
+func (s Planet) String() string {
+	return Stringify(s)
+}
+
+// GetPlanet returns details of a planet.
+func (e *UniverseEndpoint) GetPlanet(ctx context.Context, pid int) (*Planet, *Response, error) {
+	u := e.api.route("get_universe_planets_planet_id", pid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	planet := new(Planet)
+	resp, err := e.api.Do(ctx, req, planet)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return planet, resp, nil
+}
+
+// Moon holds details of a moon.
+type Moon struct {
+	MoonID   *int      `json:"moon_id,omitempty"`
+	Name     *string   `json:"name,omitempty"`
+	Position *Position `json:"position,omitempty"`
+	SystemID *int      `json:"system_id,omitempty"`
+}
+
+func (s Moon) String() string {
+	return Stringify(s)
+}
+
+// GetMoon returns details of a moon.
+func (e *UniverseEndpoint) GetMoon(ctx context.Context, mid int) (*Moon, *Response, error) {
+	u := e.api.route("get_universe_moons_moon_id", mid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	moon := new(Moon)
+	resp, err := e.api.Do(ctx, req, moon)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return moon, resp, nil
+}
+
+// AsteroidBelt holds details of an asteroid belt.
+type AsteroidBelt struct {
+	Name     *string   `json:"name,omitempty"`
+	Position *Position `json:"position,omitempty"`
+	SystemID *int      `json:"system_id,omitempty"`
+}
+
+func (s AsteroidBelt) String() string {
+	return Stringify(s)
+}
+
+// GetAsteroidBelt returns details of an asteroid belt.
+func (e *UniverseEndpoint) GetAsteroidBelt(ctx context.Context, aid int) (*AsteroidBelt, *Response, error) {
+	u := e.api.route("get_universe_asteroid_belts_asteroid_belt_id", aid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	belt := new(AsteroidBelt)
+	resp, err := e.api.Do(ctx, req, belt)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return belt, resp, nil
+}
+
+// Star holds details of a star.
+type Star struct {
+	Age           *int64   `json:"age,omitempty"`
+	Luminosity    *float64 `json:"luminosity,omitempty"`
+	Name          *string  `json:"name,omitempty"`
+	Radius        *int64   `json:"radius,omitempty"`
+	SolarSystemID *int     `json:"solar_system_id,omitempty"`
+	SpectralClass *string  `json:"spectral_class,omitempty"`
+	Temperature   *int     `json:"temperature,omitempty"`
+	TypeID        *int     `json:"type_id,omitempty"`
+}
+
+func (s Star) String() string {
+	return Stringify(s)
+}
+
+// GetStar returns details of a star.
+func (e *UniverseEndpoint) GetStar(ctx context.Context, sid int) (*Star, *Response, error) {
+	u := e.api.route("get_universe_stars_star_id", sid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	star := new(Star)
+	resp, err := e.api.Do(ctx, req, star)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return star, resp, nil
+}
+
+// ListTypes returns a page of the IDs of all published types.
+func (e *UniverseEndpoint) ListTypes(ctx context.Context, opt *ListOptions) ([]int, *Response, error) {
+	u := e.api.route("get_universe_types")
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// DogmaAttribute holds the value of a dogma attribute of a type.
+type DogmaAttribute struct {
+	AttributeID *int     `json:"attribute_id,omitempty"`
+	Value       *float64 `json:"value,omitempty"`
+}
+
+// DogmaEffect holds a dogma effect of a type.
+type DogmaEffect struct {
+	EffectID  *int  `json:"effect_id,omitempty"`
+	IsDefault *bool `json:"is_default,omitempty"`
+}
+
+// Type holds details of an inventory type.
+type Type struct {
+	Capacity        *float64          `json:"capacity,omitempty"`
+	Description     *string           `json:"description,omitempty"`
+	DogmaAttributes []*DogmaAttribute `json:"dogma_attributes,omitempty"`
+	DogmaEffects    []*DogmaEffect    `json:"dogma_effects,omitempty"`
+	GraphicID       *int              `json:"graphic_id,omitempty"`
+	GroupID         *int              `json:"group_id,omitempty"`
+	IconID          *int              `json:"icon_id,omitempty"`
+	MarketGroupID   *int              `json:"market_group_id,omitempty"`
+	Mass            *float64          `json:"mass,omitempty"`
+	Name            *string           `json:"name,omitempty"`
+	PackagedVolume  *float64          `json:"packaged_volume,omitempty"`
+	PortionSize     *int              `json:"portion_size,omitempty"`
+	Published       *bool             `json:"published,omitempty"`
+	Radius          *float64          `json:"radius,omitempty"`
+	TypeID          *int              `json:"type_id,omitempty"`
+	Volume          *float64          `json:"volume,omitempty"`
+}
+
+func (s Type) String() string {
+	return Stringify(s)
+}
+
+// GetType returns details of an inventory type.
+func (e *UniverseEndpoint) GetType(ctx context.Context, tid int, opt *I18NOptions) (*Type, *Response, error) {
+	u := e.api.route("get_universe_types_type_id", tid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	typ := new(Type)
+	resp, err := e.api.Do(ctx, req, typ)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return typ, resp, nil
+}
+
+// ListGroups returns a page of the IDs of all inventory groups.
+func (e *UniverseEndpoint) ListGroups(ctx context.Context, opt *ListOptions) ([]int, *Response, error) {
+	u := e.api.route("get_universe_groups")
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// Group holds details of an inventory group.
+type Group struct {
+	CategoryID *int    `json:"category_id,omitempty"`
+	GroupID    *int    `json:"group_id,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Published  *bool   `json:"published,omitempty"`
+	Types      []int   `json:"types,omitempty"`
+}
+
+func (s Group) String() string {
+	return Stringify(s)
+}
+
+// GetGroup returns details of an inventory group.
+func (e *UniverseEndpoint) GetGroup(ctx context.Context, gid int, opt *I18NOptions) (*Group, *Response, error) {
+	u := e.api.route("get_universe_groups_group_id", gid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group := new(Group)
+	resp, err := e.api.Do(ctx, req, group)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return group, resp, nil
+}
+
+// ListCategories returns the IDs of all inventory categories.
+func (e *UniverseEndpoint) ListCategories(ctx context.Context) ([]int, *Response, error) {
+	u := e.api.route("get_universe_categories")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// Category holds details of an inventory category.
+type Category struct {
+	CategoryID *int    `json:"category_id,omitempty"`
+	Groups     []int   `json:"groups,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Published  *bool   `json:"published,omitempty"`
+}
+
+func (s Category) String() string {
+	return Stringify(s)
+}
+
+// GetCategory returns details of an inventory category.
+func (e *UniverseEndpoint) GetCategory(ctx context.Context, cid int, opt *I18NOptions) (*Category, *Response, error) {
+	u := e.api.route("get_universe_categories_category_id", cid)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	category := new(Category)
+	resp, err := e.api.Do(ctx, req, category)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return category, resp, nil
+}
+
+// ListGraphics returns the IDs of all graphics.
+func (e *UniverseEndpoint) ListGraphics(ctx context.Context) ([]int, *Response, error) {
+	u := e.api.route("get_universe_graphics")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int
+	resp, err := e.api.Do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ids, resp, nil
+}
+
+// Graphic holds details of a graphic.
+type Graphic struct {
+	CollisionFile *string `json:"collision_file,omitempty"`
+	GraphicFile   *string `json:"graphic_file,omitempty"`
+	GraphicID     *int    `json:"graphic_id,omitempty"`
+	IconFolder    *string `json:"icon_folder,omitempty"`
+	SOFDNA        *string `json:"sof_dna,omitempty"`
+	SOFFationName *string `json:"sof_fation_name,omitempty"`
+	SOFHullName   *string `json:"sof_hull_name,omitempty"`
+	SOFRaceName   *string `json:"sof_race_name,omitempty"`
+}
+
+func (s Graphic) String() string {
+	return Stringify(s)
+}
+
+// GetGraphic returns details of a graphic.
+func (e *UniverseEndpoint) GetGraphic(ctx context.Context, gid int) (*Graphic, *Response, error) {
+	u := e.api.route("get_universe_graphics_graphic_id", gid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	graphic := new(Graphic)
+	resp, err := e.api.Do(ctx, req, graphic)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return graphic, resp, nil
+}
+
+// Race holds details of a character race.
+type Race struct {
+	AllianceID  *int    `json:"alliance_id,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	RaceID      *int    `json:"race_id,omitempty"`
+}
+
+func (s Race) String() string {
+	return Stringify(s)
+}
+
+// RacesResponse holds a list of races.
+type RacesResponse []*Race
+
+// GetRaces returns all character races.
+func (e *UniverseEndpoint) GetRaces(ctx context.Context, opt *I18NOptions) (RacesResponse, *Response, error) {
+	u := e.api.route("get_universe_races")
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var racesResponse RacesResponse
+	resp, err := e.api.Do(ctx, req, &racesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return racesResponse, resp, nil
+}
+
+// Bloodline holds details of a character bloodline.
+type Bloodline struct {
+	BloodlineID   *int    `json:"bloodline_id,omitempty"`
+	Charisma      *int    `json:"charisma,omitempty"`
+	CorporationID *int    `json:"corporation_id,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	Intelligence  *int    `json:"intelligence,omitempty"`
+	Memory        *int    `json:"memory,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	Perception    *int    `json:"perception,omitempty"`
+	RaceID        *int    `json:"race_id,omitempty"`
+	ShipTypeID    *int    `json:"ship_type_id,omitempty"`
+	Willpower     *int    `json:"willpower,omitempty"`
+}
+
+func (s Bloodline) String() string {
+	return Stringify(s)
+}
+
+// BloodlinesResponse holds a list of bloodlines.
+type BloodlinesResponse []*Bloodline
+
+// GetBloodlines returns all character bloodlines.
+func (e *UniverseEndpoint) GetBloodlines(ctx context.Context, opt *I18NOptions) (BloodlinesResponse, *Response, error) {
+	u := e.api.route("get_universe_bloodlines")
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var bloodlinesResponse BloodlinesResponse
+	resp, err := e.api.Do(ctx, req, &bloodlinesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return bloodlinesResponse, resp, nil
+}
+
+// Ancestry holds details of a character ancestry.
+type Ancestry struct {
+	BloodlineID      *int    `json:"bloodline_id,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	IconID           *int    `json:"icon_id,omitempty"`
+	ID               *int    `json:"id,omitempty"`
+	Name             *string `json:"name,omitempty"`
+	ShortDescription *string `json:"short_description,omitempty"`
+}
+
+func (s Ancestry) String() string {
+	return Stringify(s)
+}
+
+// AncestriesResponse holds a list of ancestries.
+type AncestriesResponse []*Ancestry
+
+// GetAncestries returns all character ancestries.
+func (e *UniverseEndpoint) GetAncestries(ctx context.Context, opt *I18NOptions) (AncestriesResponse, *Response, error) {
+	u := e.api.route("get_universe_ancestries")
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ancestriesResponse AncestriesResponse
+	resp, err := e.api.Do(ctx, req, &ancestriesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ancestriesResponse, resp, nil
+}
+
+// Faction holds details of a faction.
+type Faction struct {
+	CorporationID        *int     `json:"corporation_id,omitempty"`
+	Description          *string  `json:"description,omitempty"`
+	FactionID            *int     `json:"faction_id,omitempty"`
+	IsUnique             *bool    `json:"is_unique,omitempty"`
+	MilitiaCorporationID *int     `json:"militia_corporation_id,omitempty"`
+	Name                 *string  `json:"name,omitempty"`
+	SizeFactor           *float64 `json:"size_factor,omitempty"`
+	SolarSystemID        *int     `json:"solar_system_id,omitempty"`
+	StationCount         *int     `json:"station_count,omitempty"`
+	StationSystemCount   *int     `json:"station_system_count,omitempty"`
+}
+
+func (s Faction) String() string {
+	return Stringify(s)
+}
+
+// FactionsResponse holds a list of factions.
+type FactionsResponse []*Faction
+
+// GetFactions returns all factions.
+func (e *UniverseEndpoint) GetFactions(ctx context.Context, opt *I18NOptions) (FactionsResponse, *Response, error) {
+	u := e.api.route("get_universe_factions")
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var factionsResponse FactionsResponse
+	resp, err := e.api.Do(ctx, req, &factionsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return factionsResponse, resp, nil
+}
+
+// SystemJumps holds the number of ship jumps into a solar system in the last
+// hour.
+type SystemJumps struct {
+	ShipJumps *int `json:"ship_jumps,omitempty"`
+	SystemID  *int `json:"system_id,omitempty"`
+}
+
+// SystemJumpsResponse holds a list of system jump counts.
+type SystemJumpsResponse []*SystemJumps
+
+// GetSystemJumps returns the number of jumps into each solar system in the
+// last hour. Systems without jumps are not included.
+func (e *UniverseEndpoint) GetSystemJumps(ctx context.Context) (SystemJumpsResponse, *Response, error) {
+	u := e.api.route("get_universe_system_jumps")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var systemJumpsResponse SystemJumpsResponse
+	resp, err := e.api.Do(ctx, req, &systemJumpsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return systemJumpsResponse, resp, nil
+}
+
+// SystemKills holds the number of kills in a solar system in the last hour.
+type SystemKills struct {
+	NPCKills  *int `json:"npc_kills,omitempty"`
+	PodKills  *int `json:"pod_kills,omitempty"`
+	ShipKills *int `json:"ship_kills,omitempty"`
+	SystemID  *int `json:"system_id,omitempty"`
+}
+
+// SystemKillsResponse holds a list of system kill counts.
+type SystemKillsResponse []*SystemKills
+
+// GetSystemKills returns the number of kills in each solar system in the last
+// hour. Systems without kills are not included.
+func (e *UniverseEndpoint) GetSystemKills(ctx context.Context) (SystemKillsResponse, *Response, error) {
+	u := e.api.route("get_universe_system_kills")
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var systemKillsResponse SystemKillsResponse
+	resp, err := e.api.Do(ctx, req, &systemKillsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return systemKillsResponse, resp, nil
+}
+
+// NameCategory is the category of a resolved name.
+type NameCategory string
+
+// Name categories.
+const (
+	CategoryAlliance      NameCategory = "alliance"
+	CategoryCharacter     NameCategory = "character"
+	CategoryConstellation NameCategory = "constellation"
+	CategoryCorporation   NameCategory = "corporation"
+	CategoryFaction       NameCategory = "faction"
+	CategoryInventoryType NameCategory = "inventory_type"
+	CategoryRegion        NameCategory = "region"
+	CategorySolarSystem   NameCategory = "solar_system"
+	CategoryStation       NameCategory = "station"
+)
+
+// UniverseName holds the name and category of an ID.
+type UniverseName struct {
+	Category *NameCategory `json:"category,omitempty"`
+	ID       *int          `json:"id,omitempty"`
+	Name     *string       `json:"name,omitempty"`
+}
+
+func (s UniverseName) String() string {
+	return Stringify(s)
+}
+
+// UniverseNamesResponse holds a list of names.
+type UniverseNamesResponse []*UniverseName
+
+// GetNames returns the names and categories of a set of IDs. At most
+// MaxNameIDs IDs can be resolved at once and ESI fails the whole request if
+// any of the IDs is invalid; use NameResolver to resolve larger or untrusted
+// sets.
+func (e *UniverseEndpoint) GetNames(ctx context.Context, ids []int) (UniverseNamesResponse, *Response, error) {
+	u := e.api.route("post_universe_names")
+
+	req, err := e.api.NewRequest("POST", u, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var universeNamesResponse UniverseNamesResponse
+	resp, err := e.api.Do(ctx, req, &universeNamesResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return universeNamesResponse, resp, nil
+}
+
+// UniverseID holds the ID of a name.
+type UniverseID struct {
+	ID   *int    `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// UniverseIDs holds the IDs of a set of names, by category. Names that do not
+// exist are not included.
+type UniverseIDs struct {
+	Agents         []*UniverseID `json:"agents,omitempty"`
+	Alliances      []*UniverseID `json:"alliances,omitempty"`
+	Characters     []*UniverseID `json:"characters,omitempty"`
+	Constellations []*UniverseID `json:"constellations,omitempty"`
+	Corporations   []*UniverseID `json:"corporations,omitempty"`
+	Factions       []*UniverseID `json:"factions,omitempty"`
+	InventoryTypes []*UniverseID `json:"inventory_types,omitempty"`
+	Regions        []*UniverseID `json:"regions,omitempty"`
+	Stations       []*UniverseID `json:"stations,omitempty"`
+	Systems        []*UniverseID `json:"systems,omitempty"`
+}
+
+func (s UniverseIDs) String() string {
+	return Stringify(s)
+}
+
+// GetIDs returns the IDs of a set of exact names. At most MaxIDNames names
+// can be resolved at once.
+func (e *UniverseEndpoint) GetIDs(ctx context.Context, names []string, opt *I18NOptions) (*UniverseIDs, *Response, error) {
+	u := e.api.route("post_universe_ids")
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("POST", u, names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	universeIDs := new(UniverseIDs)
+	resp, err := e.api.Do(ctx, req, universeIDs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return universeIDs, resp, nil
+}
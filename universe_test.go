@@ -0,0 +1,371 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUniverseEndpoint_GetSystem(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v4/universe/systems/30000142/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"language": "en-us"})
+		fmt.Fprint(w, `
+			{
+				"constellation_id": 20000020,
+				"name": "Jita",
+				"planets": [{"moons": [40009081], "planet_id": 40009077}],
+				"position": {"x": -129064861735000000, "y": 60755306910000000, "z": 117469227060000000},
+				"security_class": "B",
+				"security_status": 0.9459131360054016,
+				"star_id": 40009076,
+				"stargates": [50001248],
+				"stations": [60003760],
+				"system_id": 30000142
+			}
+		`)
+	})
+
+	system, _, err := client.Universe.GetSystem(context.Background(), 30000142, &I18NOptions{Language: "en-us"})
+	if err != nil {
+		t.Errorf("Universe.GetSystem returned error: %v", err)
+	}
+
+	want := &System{
+		ConstellationID: Int(20000020),
+		Name:            String("Jita"),
+		Planets:         []*SystemPlanet{{Moons: []int{40009081}, PlanetID: Int(40009077)}},
+		Position: &Position{
+			X: Float64(-129064861735000000),
+			Y: Float64(60755306910000000),
+			Z: Float64(117469227060000000),
+		},
+		SecurityClass:  String("B"),
+		SecurityStatus: Float64(0.9459131360054016),
+		StarID:         Int(40009076),
+		Stargates:      []int{50001248},
+		Stations:       []int{60003760},
+		SystemID:       Int(30000142),
+	}
+	if !reflect.DeepEqual(system, want) {
+		t.Errorf("Universe.GetSystem returned %+v, want %+v", system, want)
+	}
+}
+
+func TestUniverseEndpoint_GetStargate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/universe/stargates/50001248/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			{
+				"destination": {"stargate_id": 50001249, "system_id": 30000144},
+				"name": "Stargate (Perimeter)",
+				"stargate_id": 50001248,
+				"system_id": 30000142,
+				"type_id": 29632
+			}
+		`)
+	})
+
+	stargate, _, err := client.Universe.GetStargate(context.Background(), 50001248)
+	if err != nil {
+		t.Errorf("Universe.GetStargate returned error: %v", err)
+	}
+
+	want := &Stargate{
+		Destination: &StargateDestination{StargateID: Int(50001249), SystemID: Int(30000144)},
+		Name:        String("Stargate (Perimeter)"),
+		StargateID:  Int(50001248),
+		SystemID:    Int(30000142),
+		TypeID:      Int(29632),
+	}
+	if !reflect.DeepEqual(stargate, want) {
+		t.Errorf("Universe.GetStargate returned %+v, want %+v", stargate, want)
+	}
+}
+
+func TestUniverseEndpoint_GetType(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/universe/types/587/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `
+			{
+				"dogma_attributes": [{"attribute_id": 4, "value": 1067000}],
+				"dogma_effects": [{"effect_id": 511, "is_default": false}],
+				"group_id": 25,
+				"name": "Rifter",
+				"published": true,
+				"type_id": 587,
+				"volume": 27289
+			}
+		`)
+	})
+
+	typ, _, err := client.Universe.GetType(context.Background(), 587, nil)
+	if err != nil {
+		t.Errorf("Universe.GetType returned error: %v", err)
+	}
+
+	want := &Type{
+		DogmaAttributes: []*DogmaAttribute{{AttributeID: Int(4), Value: Float64(1067000)}},
+		DogmaEffects:    []*DogmaEffect{{EffectID: Int(511), IsDefault: Bool(false)}},
+		GroupID:         Int(25),
+		Name:            String("Rifter"),
+		Published:       Bool(true),
+		TypeID:          Int(587),
+		Volume:          Float64(27289),
+	}
+	if !reflect.DeepEqual(typ, want) {
+		t.Errorf("Universe.GetType returned %+v, want %+v", typ, want)
+	}
+}
+
+func TestUniverseEndpoint_ListStructures(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/universe/structures/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"filter": "market"})
+		fmt.Fprint(w, `[1021975535893]`)
+	})
+
+	ids, _, err := client.Universe.ListStructures(context.Background(), &StructuresOptions{Filter: "market"})
+	if err != nil {
+		t.Errorf("Universe.ListStructures returned error: %v", err)
+	}
+
+	if want := []int64{1021975535893}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Universe.ListStructures returned %+v, want %+v", ids, want)
+	}
+}
+
+func TestUniverseEndpoint_GetSystemKills(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/universe/system_kills/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"npc_kills": 0, "pod_kills": 24, "ship_kills": 42, "system_id": 30002537}]`)
+	})
+
+	kills, _, err := client.Universe.GetSystemKills(context.Background())
+	if err != nil {
+		t.Errorf("Universe.GetSystemKills returned error: %v", err)
+	}
+
+	want := SystemKillsResponse{{NPCKills: Int(0), PodKills: Int(24), ShipKills: Int(42), SystemID: Int(30002537)}}
+	if !reflect.DeepEqual(kills, want) {
+		t.Errorf("Universe.GetSystemKills returned %+v, want %+v", kills, want)
+	}
+}
+
+func TestUniverseEndpoint_lists(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	for _, path := range []string{
+		"/v1/universe/systems/",
+		"/v1/universe/constellations/",
+		"/v1/universe/regions/",
+		"/v1/universe/categories/",
+		"/v1/universe/graphics/",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `[1]`)
+		})
+	}
+
+	for _, path := range []string{
+		"/v1/universe/types/",
+		"/v1/universe/groups/",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			testFormValues(t, r, values{"page": "2"})
+			fmt.Fprint(w, `[1]`)
+		})
+	}
+
+	for _, path := range []string{
+		"/v1/universe/races/",
+		"/v1/universe/bloodlines/",
+		"/v1/universe/ancestries/",
+		"/v2/universe/factions/",
+		"/v1/universe/system_jumps/",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `[{}]`)
+		})
+	}
+
+	ctx := context.Background()
+	opt := &ListOptions{Page: 2}
+
+	for name, list := range map[string]func() ([]int, *Response, error){
+		"ListSystems":        func() ([]int, *Response, error) { return client.Universe.ListSystems(ctx) },
+		"ListConstellations": func() ([]int, *Response, error) { return client.Universe.ListConstellations(ctx) },
+		"ListRegions":        func() ([]int, *Response, error) { return client.Universe.ListRegions(ctx) },
+		"ListCategories":     func() ([]int, *Response, error) { return client.Universe.ListCategories(ctx) },
+		"ListGraphics":       func() ([]int, *Response, error) { return client.Universe.ListGraphics(ctx) },
+		"ListTypes":          func() ([]int, *Response, error) { return client.Universe.ListTypes(ctx, opt) },
+		"ListGroups":         func() ([]int, *Response, error) { return client.Universe.ListGroups(ctx, opt) },
+	} {
+		if v, _, err := list(); err != nil || !reflect.DeepEqual(v, []int{1}) {
+			t.Errorf("Universe.%s returned %v, %v", name, v, err)
+		}
+	}
+
+	if v, _, err := client.Universe.GetRaces(ctx, nil); err != nil || len(v) != 1 {
+		t.Errorf("Universe.GetRaces returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetBloodlines(ctx, nil); err != nil || len(v) != 1 {
+		t.Errorf("Universe.GetBloodlines returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetAncestries(ctx, nil); err != nil || len(v) != 1 {
+		t.Errorf("Universe.GetAncestries returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetFactions(ctx, nil); err != nil || len(v) != 1 {
+		t.Errorf("Universe.GetFactions returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetSystemJumps(ctx); err != nil || len(v) != 1 {
+		t.Errorf("Universe.GetSystemJumps returned %v, %v", v, err)
+	}
+}
+
+func TestUniverseEndpoint_details(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	for _, path := range []string{
+		"/v1/universe/constellations/1/",
+		"/v1/universe/regions/1/",
+		"/v2/universe/stations/1/",
+		"/v2/universe/structures/1/",
+		"/v1/universe/planets/1/",
+		"/v1/universe/moons/1/",
+		"/v1/universe/asteroid_belts/1/",
+		"/v1/universe/stars/1/",
+		"/v1/universe/groups/1/",
+		"/v1/universe/categories/1/",
+		"/v1/universe/graphics/1/",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `{"name": "x"}`)
+		})
+	}
+
+	ctx := context.Background()
+
+	if v, _, err := client.Universe.GetConstellation(ctx, 1, nil); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetConstellation returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetRegion(ctx, 1, nil); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetRegion returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetStation(ctx, 1); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetStation returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetStructure(ctx, 1); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetStructure returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetPlanet(ctx, 1); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetPlanet returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetMoon(ctx, 1); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetMoon returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetAsteroidBelt(ctx, 1); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetAsteroidBelt returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetStar(ctx, 1); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetStar returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetGroup(ctx, 1, nil); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetGroup returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetCategory(ctx, 1, nil); err != nil || *v.Name != "x" {
+		t.Errorf("Universe.GetCategory returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Universe.GetGraphic(ctx, 1); err != nil || v.GraphicID != nil {
+		t.Errorf("Universe.GetGraphic returned %v, %v", v, err)
+	}
+}
+
+func TestUniverseEndpoint_GetNames(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/universe/names/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, "[95465499,30000142]\n")
+		fmt.Fprint(w, `
+			[
+				{"category": "character", "id": 95465499, "name": "CCP Bartender"},
+				{"category": "solar_system", "id": 30000142, "name": "Jita"}
+			]
+		`)
+	})
+
+	names, _, err := client.Universe.GetNames(context.Background(), []int{95465499, 30000142})
+	if err != nil {
+		t.Errorf("Universe.GetNames returned error: %v", err)
+	}
+
+	character, system := CategoryCharacter, CategorySolarSystem
+	want := UniverseNamesResponse{
+		{Category: &character, ID: Int(95465499), Name: String("CCP Bartender")},
+		{Category: &system, ID: Int(30000142), Name: String("Jita")},
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Universe.GetNames returned %+v, want %+v", names, want)
+	}
+}
+
+func TestUniverseEndpoint_GetIDs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/universe/ids/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"language": "de"})
+		testBody(t, r, `["Jita"]`+"\n")
+		fmt.Fprint(w, `{"systems": [{"id": 30000142, "name": "Jita"}]}`)
+	})
+
+	ids, _, err := client.Universe.GetIDs(context.Background(), []string{"Jita"}, &I18NOptions{Language: "de"})
+	if err != nil {
+		t.Errorf("Universe.GetIDs returned error: %v", err)
+	}
+
+	want := &UniverseIDs{Systems: []*UniverseID{{ID: Int(30000142), Name: String("Jita")}}}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("Universe.GetIDs returned %+v, want %+v", ids, want)
+	}
+}
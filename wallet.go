@@ -0,0 +1,253 @@
+package esi
+
+import "context"
+
+// WalletEndpoint handles communication with the wallet related methods of the
+// ESI API.
+type WalletEndpoint endpoint
+
+// RefType is the reference type of a wallet journal entry. It describes the
+// kind of transaction that caused the entry.
+type RefType string
+
+// Wallet journal reference types.
+const (
+	RefAccelerationGateFee              RefType = "acceleration_gate_fee"
+	RefAgentMissionReward               RefType = "agent_mission_reward"
+	RefAgentMissionTimeBonusReward      RefType = "agent_mission_time_bonus_reward"
+	RefAllianceMaintainanceFee          RefType = "alliance_maintainance_fee"
+	RefAssetSafetyRecoveryTax           RefType = "asset_safety_recovery_tax"
+	RefBountyPrize                      RefType = "bounty_prize"
+	RefBountyPrizes                     RefType = "bounty_prizes"
+	RefBrokersFee                       RefType = "brokers_fee"
+	RefCloneActivation                  RefType = "clone_activation"
+	RefCloneTransfer                    RefType = "clone_transfer"
+	RefContractBrokersFee               RefType = "contract_brokers_fee"
+	RefContractCollateral               RefType = "contract_collateral"
+	RefContractDeposit                  RefType = "contract_deposit"
+	RefContractPrice                    RefType = "contract_price"
+	RefContractPricePaymentCorp         RefType = "contract_price_payment_corp"
+	RefContractReward                   RefType = "contract_reward"
+	RefContractRewardDeposited          RefType = "contract_reward_deposited"
+	RefContractSalesTax                 RefType = "contract_sales_tax"
+	RefCopying                          RefType = "copying"
+	RefCorporationAccountWithdrawal     RefType = "corporation_account_withdrawal"
+	RefCorporationDividendPayment       RefType = "corporation_dividend_payment"
+	RefCorporationLogoChangeCost        RefType = "corporation_logo_change_cost"
+	RefCorporationPayment               RefType = "corporation_payment"
+	RefCorporationRegistrationFee       RefType = "corporation_registration_fee"
+	RefCspa                             RefType = "cspa"
+	RefDailyChallengeReward             RefType = "daily_challenge_reward"
+	RefDatacoreFee                      RefType = "datacore_fee"
+	RefFactorySlotRentalFee             RefType = "factory_slot_rental_fee"
+	RefIndustryJobTax                   RefType = "industry_job_tax"
+	RefIndustryJobTaxCorporation        RefType = "industry_job_tax_corporation"
+	RefInfrastructureHubMaintenance     RefType = "infrastructure_hub_maintenance"
+	RefInsurance                        RefType = "insurance"
+	RefJumpCloneActivationFee           RefType = "jump_clone_activation_fee"
+	RefJumpCloneInstallationFee         RefType = "jump_clone_installation_fee"
+	RefManufacturing                    RefType = "manufacturing"
+	RefMarketEscrow                     RefType = "market_escrow"
+	RefMarketFinePaid                   RefType = "market_fine_paid"
+	RefMarketTransaction                RefType = "market_transaction"
+	RefMedalCreation                    RefType = "medal_creation"
+	RefMedalIssued                      RefType = "medal_issued"
+	RefMissionReward                    RefType = "mission_reward"
+	RefMoonMiningExtractionTax          RefType = "moon_mining_extraction_tax"
+	RefOfficeRentalFee                  RefType = "office_rental_fee"
+	RefPlanetaryConstruction            RefType = "planetary_construction"
+	RefPlanetaryConstructionCorporation RefType = "planetary_construction_corporation"
+	RefPlanetaryExportTax               RefType = "planetary_export_tax"
+	RefPlanetaryImportTax               RefType = "planetary_import_tax"
+	RefPlayerDonation                   RefType = "player_donation"
+	RefPlayerTrading                    RefType = "player_trading"
+	RefProjectDiscoveryReward           RefType = "project_discovery_reward"
+	RefRepairBill                       RefType = "repair_bill"
+	RefReprocessingTax                  RefType = "reprocessing_tax"
+	RefResearchingMaterialProductivity  RefType = "researching_material_productivity"
+	RefResearchingTechnology            RefType = "researching_technology"
+	RefResearchingTimeProductivity      RefType = "researching_time_productivity"
+	RefResourceWarsReward               RefType = "resource_wars_reward"
+	RefReverseEngineering               RefType = "reverse_engineering"
+	RefSkillPurchase                    RefType = "skill_purchase"
+	RefSovereignityBill                 RefType = "sovereignity_bill"
+	RefStructureGateJump                RefType = "structure_gate_jump"
+	RefStructureGateJumpFeeCorporation  RefType = "structure_gate_jump_fee_corporation"
+	RefTransactionTax                   RefType = "transaction_tax"
+	RefUpkeepAdjustmentFee              RefType = "upkeep_adjustment_fee"
+	RefWarFee                           RefType = "war_fee"
+	RefWarFeeSurrender                  RefType = "war_fee_surrender"
+)
+
+// GetCharacterBalance returns the wallet balance of a character.
+func (e *WalletEndpoint) GetCharacterBalance(ctx context.Context, cid int) (float64, *Response, error) {
+	u := e.api.route("get_characters_character_id_wallet", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var balance float64
+	resp, err := e.api.Do(ctx, req, &balance)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return balance, resp, nil
+}
+
+// JournalEntry holds a single wallet journal entry.
+type JournalEntry struct {
+	Amount        *float64   `json:"amount,omitempty"`
+	Balance       *float64   `json:"balance,omitempty"`
+	ContextID     *int64     `json:"context_id,omitempty"`
+	ContextIDType *string    `json:"context_id_type,omitempty"`
+	Date          *Timestamp `json:"date,omitempty"`
+	Description   *string    `json:"description,omitempty"`
+	FirstPartyID  *int       `json:"first_party_id,omitempty"`
+	ID            *int64     `json:"id,omitempty"`
+	Reason        *string    `json:"reason,omitempty"`
+	RefType       *RefType   `json:"ref_type,omitempty"`
+	SecondPartyID *int       `json:"second_party_id,omitempty"`
+	Tax           *float64   `json:"tax,omitempty"`
+	TaxReceiverID *int       `json:"tax_receiver_id,omitempty"`
+}
+
+func (s JournalEntry) String() string {
+	return Stringify(s)
+}
+
+// JournalResponse holds a list of wallet journal entries.
+type JournalResponse []*JournalEntry
+
+// GetCharacterJournal returns a page of the wallet journal of a character.
+// Entries are returned newest first and only cover the last 30 days.
+func (e *WalletEndpoint) GetCharacterJournal(ctx context.Context, cid int, opt *ListOptions) (JournalResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_wallet_journal", cid)
+	return e.getJournal(ctx, u, opt)
+}
+
+// GetCorporationJournal returns a page of the journal of a corporation wallet
+// division. Entries are returned newest first and only cover the last 30 days.
+func (e *WalletEndpoint) GetCorporationJournal(ctx context.Context, cid int, division int, opt *ListOptions) (JournalResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_wallets_division_journal", cid, division)
+	return e.getJournal(ctx, u, opt)
+}
+
+func (e *WalletEndpoint) getJournal(ctx context.Context, u string, opt *ListOptions) (JournalResponse, *Response, error) {
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var journalResponse JournalResponse
+	resp, err := e.api.Do(ctx, req, &journalResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return journalResponse, resp, nil
+}
+
+// Transaction holds a single wallet transaction.
+type Transaction struct {
+	ClientID      *int       `json:"client_id,omitempty"`
+	Date          *Timestamp `json:"date,omitempty"`
+	IsBuy         *bool      `json:"is_buy,omitempty"`
+	IsPersonal    *bool      `json:"is_personal,omitempty"`
+	JournalRefID  *int64     `json:"journal_ref_id,omitempty"`
+	LocationID    *int64     `json:"location_id,omitempty"`
+	Quantity      *int       `json:"quantity,omitempty"`
+	TransactionID *int64     `json:"transaction_id,omitempty"`
+	TypeID        *int       `json:"type_id,omitempty"`
+	UnitPrice     *float64   `json:"unit_price,omitempty"`
+}
+
+func (s Transaction) String() string {
+	return Stringify(s)
+}
+
+// TransactionsResponse holds a list of wallet transactions.
+type TransactionsResponse []*Transaction
+
+// TransactionsOptions specifies the optional parameters to the
+// WalletEndpoint.GetCharacterTransactions and
+// WalletEndpoint.GetCorporationTransactions methods.
+type TransactionsOptions struct {
+	// FromID only returns transactions with an ID lower than or equal to
+	// FromID. Use it to walk back through the transaction history.
+	FromID int64 `url:"from_id,omitempty"`
+}
+
+// GetCharacterTransactions returns the wallet transactions of a character,
+// newest first.
+func (e *WalletEndpoint) GetCharacterTransactions(ctx context.Context, cid int, opt *TransactionsOptions) (TransactionsResponse, *Response, error) {
+	u := e.api.route("get_characters_character_id_wallet_transactions", cid)
+	return e.getTransactions(ctx, u, opt)
+}
+
+// GetCorporationTransactions returns the transactions of a corporation wallet
+// division, newest first.
+func (e *WalletEndpoint) GetCorporationTransactions(ctx context.Context, cid int, division int, opt *TransactionsOptions) (TransactionsResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_wallets_division_transactions", cid, division)
+	return e.getTransactions(ctx, u, opt)
+}
+
+func (e *WalletEndpoint) getTransactions(ctx context.Context, u string, opt *TransactionsOptions) (TransactionsResponse, *Response, error) {
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var transactionsResponse TransactionsResponse
+	resp, err := e.api.Do(ctx, req, &transactionsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return transactionsResponse, resp, nil
+}
+
+// WalletDivision holds the balance of a corporation wallet division.
+type WalletDivision struct {
+	Balance  *float64 `json:"balance,omitempty"`
+	Division *int     `json:"division,omitempty"`
+}
+
+func (s WalletDivision) String() string {
+	return Stringify(s)
+}
+
+// WalletDivisionsResponse holds the balances of the wallet divisions of a
+// corporation.
+type WalletDivisionsResponse []*WalletDivision
+
+// GetCorporationBalances returns the balances of the wallet divisions of a
+// corporation.
+func (e *WalletEndpoint) GetCorporationBalances(ctx context.Context, cid int) (WalletDivisionsResponse, *Response, error) {
+	u := e.api.route("get_corporations_corporation_id_wallets", cid)
+
+	req, err := e.api.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var walletDivisionsResponse WalletDivisionsResponse
+	resp, err := e.api.Do(ctx, req, &walletDivisionsResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return walletDivisionsResponse, resp, nil
+}
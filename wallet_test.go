@@ -0,0 +1,175 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWalletEndpoint_GetCharacterBalance(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/characters/42/wallet/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `29500.01`)
+	})
+
+	balance, _, err := client.Wallet.GetCharacterBalance(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Wallet.GetCharacterBalance returned error: %v", err)
+	}
+
+	if want := 29500.01; balance != want {
+		t.Errorf("Wallet.GetCharacterBalance returned %v, want %v", balance, want)
+	}
+}
+
+func TestWalletEndpoint_GetCharacterJournal(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v6/characters/42/wallet/journal/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "2"})
+		fmt.Fprint(w, `
+			[
+				{
+					"amount": -100000,
+					"balance": 500000.4316,
+					"context_id": 4,
+					"context_id_type": "contract_id",
+					"date": "2018-02-23T14:31:32Z",
+					"description": "Contract Deposit",
+					"first_party_id": 2112625428,
+					"id": 89,
+					"ref_type": "contract_deposit",
+					"second_party_id": 1000132
+				}
+			]
+		`)
+	})
+
+	journal, _, err := client.Wallet.GetCharacterJournal(context.Background(), 42, &ListOptions{Page: 2})
+	if err != nil {
+		t.Errorf("Wallet.GetCharacterJournal returned error: %v", err)
+	}
+
+	refType := RefContractDeposit
+	want := JournalResponse{
+		{
+			Amount:        Float64(-100000),
+			Balance:       Float64(500000.4316),
+			ContextID:     Int64(4),
+			ContextIDType: String("contract_id"),
+			Date:          &Timestamp{time.Date(2018, 2, 23, 14, 31, 32, 0, time.UTC)},
+			Description:   String("Contract Deposit"),
+			FirstPartyID:  Int(2112625428),
+			ID:            Int64(89),
+			RefType:       &refType,
+			SecondPartyID: Int(1000132),
+		},
+	}
+	if !reflect.DeepEqual(journal, want) {
+		t.Errorf("Wallet.GetCharacterJournal returned %+v, want %+v", journal, want)
+	}
+}
+
+func TestWalletEndpoint_GetCharacterTransactions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/characters/42/wallet/transactions/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"from_id": "1234567890"})
+		fmt.Fprint(w, `
+			[
+				{
+					"client_id": 54321,
+					"date": "2016-10-24T09:00:00Z",
+					"is_buy": true,
+					"is_personal": true,
+					"journal_ref_id": 67890,
+					"location_id": 60014719,
+					"quantity": 1,
+					"transaction_id": 1234567890,
+					"type_id": 587,
+					"unit_price": 1
+				}
+			]
+		`)
+	})
+
+	transactions, _, err := client.Wallet.GetCharacterTransactions(context.Background(), 42, &TransactionsOptions{FromID: 1234567890})
+	if err != nil {
+		t.Errorf("Wallet.GetCharacterTransactions returned error: %v", err)
+	}
+
+	want := TransactionsResponse{
+		{
+			ClientID:      Int(54321),
+			Date:          &Timestamp{time.Date(2016, 10, 24, 9, 0, 0, 0, time.UTC)},
+			IsBuy:         Bool(true),
+			IsPersonal:    Bool(true),
+			JournalRefID:  Int64(67890),
+			LocationID:    Int64(60014719),
+			Quantity:      Int(1),
+			TransactionID: Int64(1234567890),
+			TypeID:        Int(587),
+			UnitPrice:     Float64(1),
+		},
+	}
+	if !reflect.DeepEqual(transactions, want) {
+		t.Errorf("Wallet.GetCharacterTransactions returned %+v, want %+v", transactions, want)
+	}
+}
+
+func TestWalletEndpoint_GetCorporationBalances(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/corporations/42/wallets/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"balance": 123.45, "division": 1}]`)
+	})
+
+	balances, _, err := client.Wallet.GetCorporationBalances(context.Background(), 42)
+	if err != nil {
+		t.Errorf("Wallet.GetCorporationBalances returned error: %v", err)
+	}
+
+	want := WalletDivisionsResponse{{Balance: Float64(123.45), Division: Int(1)}}
+	if !reflect.DeepEqual(balances, want) {
+		t.Errorf("Wallet.GetCorporationBalances returned %+v, want %+v", balances, want)
+	}
+}
+
+func TestWalletEndpoint_corporation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v4/corporations/42/wallets/3/journal/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"page": "2"})
+		fmt.Fprint(w, `[{}]`)
+	})
+
+	mux.HandleFunc("/v1/corporations/42/wallets/3/transactions/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"from_id": "7"})
+		fmt.Fprint(w, `[{}]`)
+	})
+
+	ctx := context.Background()
+
+	if v, _, err := client.Wallet.GetCorporationJournal(ctx, 42, 3, &ListOptions{Page: 2}); err != nil || len(v) != 1 {
+		t.Errorf("Wallet.GetCorporationJournal returned %v, %v", v, err)
+	}
+
+	if v, _, err := client.Wallet.GetCorporationTransactions(ctx, 42, 3, &TransactionsOptions{FromID: 7}); err != nil || len(v) != 1 {
+		t.Errorf("Wallet.GetCorporationTransactions returned %v, %v", v, err)
+	}
+}
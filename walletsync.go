@@ -0,0 +1,142 @@
+package esi
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// JournalWindow is how far back ESI serves wallet journal entries.
+const JournalWindow = 30 * 24 * time.Hour
+
+// A JournalCheckpoint records how far a wallet journal has been synced. The
+// zero value syncs the entire journal. Store the checkpoint returned by a sync
+// and pass it to the next one.
+type JournalCheckpoint struct {
+	// LastID is the ID of the newest entry seen.
+	LastID int64
+
+	// LastDate is the date of the newest entry seen.
+	LastDate time.Time
+
+	// SyncedAt is the time of the sync that returned the checkpoint.
+	SyncedAt time.Time
+}
+
+// A JournalSync is the result of a wallet journal sync.
+type JournalSync struct {
+	// Entries holds the entries added since the checkpoint, oldest first.
+	Entries JournalResponse
+
+	// Checkpoint is the checkpoint to pass to the next sync.
+	Checkpoint JournalCheckpoint
+
+	// Gap is set if entries made since the checkpoint may be missing. This
+	// happens when the checkpoint was not found in the journal and the
+	// previous sync is older than JournalWindow, in which case entries made
+	// in between are no longer available from ESI.
+	Gap bool
+}
+
+// SyncCharacterJournal fetches the entries added to the wallet journal of a
+// character since the checkpoint cp.
+func (e *WalletEndpoint) SyncCharacterJournal(ctx context.Context, cid int, cp JournalCheckpoint) (*JournalSync, error) {
+	return e.syncJournal(ctx, cp, func(opt *ListOptions) (JournalResponse, *Response, error) {
+		return e.GetCharacterJournal(ctx, cid, opt)
+	})
+}
+
+// SyncCorporationJournal fetches the entries added to the journal of a
+// corporation wallet division since the checkpoint cp.
+func (e *WalletEndpoint) SyncCorporationJournal(ctx context.Context, cid int, division int, cp JournalCheckpoint) (*JournalSync, error) {
+	return e.syncJournal(ctx, cp, func(opt *ListOptions) (JournalResponse, *Response, error) {
+		return e.GetCorporationJournal(ctx, cid, division, opt)
+	})
+}
+
+// syncJournal walks the journal pages newest first until it reaches an entry
+// at or before the checkpoint. Entries that move to the next page while
+// paging are only returned once.
+func (e *WalletEndpoint) syncJournal(ctx context.Context, cp JournalCheckpoint, fetch func(opt *ListOptions) (JournalResponse, *Response, error)) (*JournalSync, error) {
+	seen := make(map[int64]bool)
+	sync := &JournalSync{Checkpoint: cp}
+	sync.Checkpoint.SyncedAt = now()
+
+	found := false
+	var oldest time.Time // date of the oldest entry fetched
+	for page, pages := 1, 1; page <= pages && !found; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		entries, resp, err := fetch(&ListOptions{Page: page})
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.Pages > pages {
+			pages = resp.Pages
+		}
+
+		for _, entry := range entries {
+			if entry.ID == nil {
+				continue
+			}
+
+			id := *entry.ID
+			if cp.LastID != 0 && id <= cp.LastID {
+				found = true
+				break
+			}
+
+			if seen[id] {
+				continue
+			}
+
+			seen[id] = true
+			sync.Entries = append(sync.Entries, entry)
+
+			if entry.Date != nil && (oldest.IsZero() || entry.Date.Before(oldest)) {
+				oldest = entry.Date.Time
+			}
+		}
+	}
+
+	if cp.LastID != 0 && !found {
+		sync.Gap = journalGap(cp, oldest, sync.Checkpoint.SyncedAt)
+	}
+
+	sort.Slice(sync.Entries, func(i, j int) bool {
+		return *sync.Entries[i].ID < *sync.Entries[j].ID
+	})
+
+	if n := len(sync.Entries); n > 0 {
+		newest := sync.Entries[n-1]
+		sync.Checkpoint.LastID = *newest.ID
+		if newest.Date != nil {
+			sync.Checkpoint.LastDate = newest.Date.Time
+		}
+	}
+
+	return sync, nil
+}
+
+// journalGap reports whether entries made since the checkpoint cp, which was
+// not found in the journal, may have aged out of the journal window by the
+// time synced. oldest is the date of the oldest entry fetched, if any.
+func journalGap(cp JournalCheckpoint, oldest, synced time.Time) bool {
+	// the journal has been complete up to the later of the newest entry and
+	// the previous sync
+	complete := cp.LastDate
+	if cp.SyncedAt.After(complete) {
+		complete = cp.SyncedAt
+	}
+
+	// everything since then is still in the window
+	if !complete.Before(synced.Add(-JournalWindow)) {
+		return false
+	}
+
+	// unless the fetched entries reach back that far
+	return oldest.IsZero() || oldest.After(complete)
+}
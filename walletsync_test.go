@@ -0,0 +1,252 @@
+package esi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// journalHandler serves the given entry IDs, newest first, in pages of
+// perPage entries. Entry dates are derived from their IDs.
+func journalHandler(ids []int64, perPage int, calls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+
+		page := 1
+		fmt.Sscan(r.FormValue("page"), &page)
+
+		pages := (len(ids) + perPage - 1) / perPage
+		w.Header().Set("X-Pages", fmt.Sprint(pages))
+
+		var entries []string
+		for i := (page - 1) * perPage; i < page*perPage && i < len(ids); i++ {
+			date := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(ids[i]) * time.Hour)
+			entries = append(entries, fmt.Sprintf(`{"id": %d, "date": %q}`, ids[i], date.Format(time.RFC3339)))
+		}
+
+		fmt.Fprintf(w, "[%s]", strings.Join(entries, ","))
+	}
+}
+
+func journalIDs(entries JournalResponse) []int64 {
+	var ids []int64
+	for _, e := range entries {
+		ids = append(ids, *e.ID)
+	}
+
+	return ids
+}
+
+// setNow sets the time returned by now, returning a function restoring it.
+func setNow(t time.Time) func() {
+	old := now
+	now = func() time.Time { return t }
+
+	return func() { now = old }
+}
+
+func TestWalletEndpoint_SyncCharacterJournal(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	synced := time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+	defer setNow(synced)()
+
+	var calls int
+	mux.HandleFunc("/v6/characters/42/wallet/journal/", journalHandler([]int64{9, 8, 7, 6, 5, 4, 3, 2, 1}, 3, &calls))
+
+	sync, err := client.Wallet.SyncCharacterJournal(context.Background(), 42, JournalCheckpoint{LastID: 5})
+	if err != nil {
+		t.Fatalf("Wallet.SyncCharacterJournal returned error: %v", err)
+	}
+
+	if want := []int64{6, 7, 8, 9}; !reflect.DeepEqual(journalIDs(sync.Entries), want) {
+		t.Errorf("Wallet.SyncCharacterJournal returned entries %v, want %v", journalIDs(sync.Entries), want)
+	}
+
+	if sync.Gap {
+		t.Errorf("Wallet.SyncCharacterJournal reported a gap")
+	}
+
+	want := JournalCheckpoint{LastID: 9, LastDate: time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC), SyncedAt: synced}
+	if sync.Checkpoint != want {
+		t.Errorf("Wallet.SyncCharacterJournal returned checkpoint %+v, want %+v", sync.Checkpoint, want)
+	}
+
+	// the checkpoint is on the second page; the third is never fetched
+	if calls != 2 {
+		t.Errorf("Wallet.SyncCharacterJournal made %d calls, want 2", calls)
+	}
+}
+
+func TestWalletEndpoint_SyncCharacterJournal_full(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/v6/characters/42/wallet/journal/", journalHandler([]int64{3, 2, 1}, 2, &calls))
+
+	sync, err := client.Wallet.SyncCharacterJournal(context.Background(), 42, JournalCheckpoint{})
+	if err != nil {
+		t.Fatalf("Wallet.SyncCharacterJournal returned error: %v", err)
+	}
+
+	if want := []int64{1, 2, 3}; !reflect.DeepEqual(journalIDs(sync.Entries), want) {
+		t.Errorf("Wallet.SyncCharacterJournal returned entries %v, want %v", journalIDs(sync.Entries), want)
+	}
+
+	if sync.Gap {
+		t.Errorf("Wallet.SyncCharacterJournal reported a gap")
+	}
+}
+
+func TestWalletEndpoint_SyncCharacterJournal_gap(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// entries up to 20 have aged out of the journal window
+	var calls int
+	mux.HandleFunc("/v6/characters/42/wallet/journal/", journalHandler([]int64{23, 22, 21}, 2, &calls))
+
+	sync, err := client.Wallet.SyncCharacterJournal(context.Background(), 42, JournalCheckpoint{LastID: 10})
+	if err != nil {
+		t.Fatalf("Wallet.SyncCharacterJournal returned error: %v", err)
+	}
+
+	if want := []int64{21, 22, 23}; !reflect.DeepEqual(journalIDs(sync.Entries), want) {
+		t.Errorf("Wallet.SyncCharacterJournal returned entries %v, want %v", journalIDs(sync.Entries), want)
+	}
+
+	if !sync.Gap {
+		t.Errorf("Wallet.SyncCharacterJournal did not report a gap")
+	}
+}
+
+func TestWalletEndpoint_SyncCharacterJournal_agedOut(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	defer setNow(time.Date(2018, 1, 3, 0, 0, 0, 0, time.UTC))()
+
+	// the checkpoint has aged out, but the previous sync is recent enough
+	// for all entries made since to be in the journal
+	var calls int
+	mux.HandleFunc("/v6/characters/42/wallet/journal/", journalHandler([]int64{23, 22, 21}, 2, &calls))
+
+	cp := JournalCheckpoint{
+		LastID:   10,
+		LastDate: time.Date(2017, 11, 1, 0, 0, 0, 0, time.UTC),
+		SyncedAt: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	sync, err := client.Wallet.SyncCharacterJournal(context.Background(), 42, cp)
+	if err != nil {
+		t.Fatalf("Wallet.SyncCharacterJournal returned error: %v", err)
+	}
+
+	if want := []int64{21, 22, 23}; !reflect.DeepEqual(journalIDs(sync.Entries), want) {
+		t.Errorf("Wallet.SyncCharacterJournal returned entries %v, want %v", journalIDs(sync.Entries), want)
+	}
+
+	if sync.Gap {
+		t.Errorf("Wallet.SyncCharacterJournal reported a gap")
+	}
+}
+
+func TestWalletEndpoint_SyncCharacterJournal_empty(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/v6/characters/42/wallet/journal/", journalHandler(nil, 2, &calls))
+
+	synced := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	restore := setNow(synced)
+	defer restore()
+
+	// the previous sync is older than the journal window
+	cp := JournalCheckpoint{
+		LastID:   10,
+		LastDate: time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC),
+		SyncedAt: time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	sync, err := client.Wallet.SyncCharacterJournal(context.Background(), 42, cp)
+	if err != nil {
+		t.Fatalf("Wallet.SyncCharacterJournal returned error: %v", err)
+	}
+
+	if len(sync.Entries) != 0 {
+		t.Errorf("Wallet.SyncCharacterJournal returned entries %v, want none", journalIDs(sync.Entries))
+	}
+
+	if !sync.Gap {
+		t.Errorf("Wallet.SyncCharacterJournal did not report a gap")
+	}
+
+	want := cp
+	want.SyncedAt = synced
+	if sync.Checkpoint != want {
+		t.Errorf("Wallet.SyncCharacterJournal returned checkpoint %+v, want %+v", sync.Checkpoint, want)
+	}
+
+	// the gap is reported once; the next sync starts from the previous one
+	restore()
+	defer setNow(synced.Add(24 * time.Hour))()
+
+	sync, err = client.Wallet.SyncCharacterJournal(context.Background(), 42, sync.Checkpoint)
+	if err != nil {
+		t.Fatalf("Wallet.SyncCharacterJournal returned error: %v", err)
+	}
+
+	if sync.Gap {
+		t.Errorf("Wallet.SyncCharacterJournal reported a gap again")
+	}
+}
+
+func TestWalletEndpoint_SyncCorporationJournal_dedupe(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// a new entry arrives between the first and second page, shifting entry
+	// 4 onto the second page
+	mux.HandleFunc("/v4/corporations/42/wallets/1/journal/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Pages", "2")
+		switch r.FormValue("page") {
+		case "1":
+			fmt.Fprint(w, `[{"id": 5}, {"id": 4}]`)
+		case "2":
+			fmt.Fprint(w, `[{"id": 4}, {"id": 3}, {"id": 2}]`)
+		}
+	})
+
+	sync, err := client.Wallet.SyncCorporationJournal(context.Background(), 42, 1, JournalCheckpoint{LastID: 2})
+	if err != nil {
+		t.Fatalf("Wallet.SyncCorporationJournal returned error: %v", err)
+	}
+
+	if want := []int64{3, 4, 5}; !reflect.DeepEqual(journalIDs(sync.Entries), want) {
+		t.Errorf("Wallet.SyncCorporationJournal returned entries %v, want %v", journalIDs(sync.Entries), want)
+	}
+
+	if sync.Gap {
+		t.Errorf("Wallet.SyncCorporationJournal reported a gap")
+	}
+}
+
+func TestWalletEndpoint_SyncCharacterJournal_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v6/characters/42/wallet/journal/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "forbidden"}`, http.StatusForbidden)
+	})
+
+	if _, err := client.Wallet.SyncCharacterJournal(context.Background(), 42, JournalCheckpoint{}); err == nil {
+		t.Errorf("Wallet.SyncCharacterJournal returned no error")
+	}
+}
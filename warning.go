@@ -0,0 +1,90 @@
+package esi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Warning codes used by ESI.
+const (
+	// WarningNewVersion indicates that a newer version of the route is
+	// available.
+	WarningNewVersion = 199
+
+	// WarningDeprecated indicates that the route is deprecated and will be
+	// removed.
+	WarningDeprecated = 299
+)
+
+// A Warning is a warning returned by ESI in a Warning header.
+type Warning struct {
+	// Code is the warning code, such as WarningNewVersion or
+	// WarningDeprecated.
+	Code int
+
+	// Agent is the warning agent; usually "-".
+	Agent string
+
+	// Message is the warning text.
+	Message string
+
+	// Route is the path of the request that caused the warning.
+	Route string
+}
+
+// Deprecated reports whether the warning is a deprecation warning.
+func (w Warning) Deprecated() bool {
+	return w.Code == WarningDeprecated
+}
+
+func (w Warning) String() string {
+	return strconv.Itoa(w.Code) + " " + w.Route + ": " + w.Message
+}
+
+// parseWarnings parses the Warning headers of r.
+func parseWarnings(r *http.Response) []Warning {
+	var warnings []Warning
+
+	var route string
+	if r.Request != nil && r.Request.URL != nil {
+		route = r.Request.URL.Path
+	}
+
+	for _, v := range r.Header["Warning"] {
+		if w, ok := parseWarning(v); ok {
+			w.Route = route
+			warnings = append(warnings, w)
+		}
+	}
+
+	return warnings
+}
+
+// parseWarning parses a single warning of the form
+//
+//	code agent "text"
+//
+// The quotes around the text are optional.
+func parseWarning(s string) (Warning, bool) {
+	var w Warning
+
+	fields := strings.SplitN(strings.TrimSpace(s), " ", 3)
+	if len(fields) < 2 {
+		return w, false
+	}
+
+	code, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return w, false
+	}
+
+	w.Code = code
+	w.Agent = fields[1]
+
+	if len(fields) == 3 {
+		w.Message = strings.Trim(strings.TrimSpace(fields[2]), `"`)
+	}
+
+	return w, true
+}
@@ -0,0 +1,74 @@
+package esi
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWarning(t *testing.T) {
+	var tests = []struct {
+		in   string
+		want Warning
+		ok   bool
+	}{
+		{`299 - "This route is deprecated."`, Warning{Code: 299, Agent: "-", Message: "This route is deprecated."}, true},
+		{`199 - This route has an upgrade available`, Warning{Code: 199, Agent: "-", Message: "This route has an upgrade available"}, true},
+		{`299 -`, Warning{Code: 299, Agent: "-"}, true},
+		{`invalid`, Warning{}, false},
+		{`abc - "text"`, Warning{}, false},
+	}
+
+	for i, tt := range tests {
+		got, ok := parseWarning(tt.in)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("%d. parseWarning(%q) => %+v, %v, want %+v, %v", i, tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDo_warnings(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var out bytes.Buffer
+	client.Logging.Error = log.New(&out, "", 0)
+
+	mux.HandleFunc("/v1/fleets/42/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Warning", `199 - "This route has an upgrade available"`)
+		w.Header().Add("Warning", `299 - "This route is deprecated"`)
+	})
+
+	var deprecations []Warning
+	client.OnDeprecation = func(resp *Response, w Warning) {
+		deprecations = append(deprecations, w)
+	}
+
+	_, resp, err := client.Fleets.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Fleets.Get returned error: %v", err)
+	}
+
+	route := baseURLPath + "/v1/fleets/42/"
+	want := []Warning{
+		{Code: WarningNewVersion, Agent: "-", Message: "This route has an upgrade available", Route: route},
+		{Code: WarningDeprecated, Agent: "-", Message: "This route is deprecated", Route: route},
+	}
+
+	if !reflect.DeepEqual(resp.Warnings, want) {
+		t.Errorf("unexpected Warnings; got %+v, want %+v", resp.Warnings, want)
+	}
+
+	if !reflect.DeepEqual(deprecations, want[1:]) {
+		t.Errorf("unexpected OnDeprecation calls; got %+v, want %+v", deprecations, want[1:])
+	}
+
+	// deprecations handled by OnDeprecation are not logged; other warnings are
+	if logged := out.String(); !strings.Contains(logged, "upgrade available") || strings.Contains(logged, "deprecated") {
+		t.Errorf("unexpected log output: %q", logged)
+	}
+}